@@ -31,29 +31,59 @@ func (c *Client) GetAccessToken(owner int, tokenId string) (*AccessToken, error)
 	return &token, nil
 }
 
-func (c *Client) CreateAccessToken(owner int, creationRequest AccessToken) (*AccessToken, error) {
-	rb, err := json.Marshal(creationRequest)
+// Lists the metadata of Access Tokens, optionally only those owned by a
+// single User. Secrets are never returned by the server.
+func (c *Client) ListAccessTokens(owner *int) ([]AccessToken, error) {
+	url := fmt.Sprintf("%s/access-token", c.HostURL)
+	if owner != nil {
+		url = fmt.Sprintf("%s/user/%s/access-token", c.HostURL, strconv.Itoa(*owner))
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user/%s/access-token", c.HostURL, strconv.Itoa(owner)), strings.NewReader(string(rb)))
+	body, err := c.doRequest(req)
 	if err != nil {
 		return nil, err
 	}
+	if body == nil {
+		return nil, nil
+	}
 
-	body, err := c.doRequest(req)
+	tokens := []AccessToken{}
+	err = json.Unmarshal(body, &tokens)
 	if err != nil {
 		return nil, err
 	}
 
+	return tokens, nil
+}
+
+func (c *Client) CreateAccessToken(owner int, creationRequest AccessToken) (*AccessToken, []string, error) {
+	rb, err := json.Marshal(creationRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user/%s/access-token", c.HostURL, strconv.Itoa(owner)), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, warnings, err := c.doWriteRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var token AccessToken
 	err = json.Unmarshal(body, &token)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return &token, nil
+	return &token, warnings, nil
 }
 
 func (c *Client) DeleteAccessToken(owner int, tokenId string) error {
@@ -30,49 +30,71 @@ func (c *Client) GetAttributeRestriction(attributeID string) (*AttributeRestrict
 	return &attribute, nil
 }
 
-func (c *Client) CreateAttributeRestriction(creationRequest AttributeRestriction) (*AttributeRestriction, error) {
-	rb, err := json.Marshal(creationRequest)
+func (c *Client) ListAttributeRestrictions() ([]AttributeRestriction, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/allowed-attribute", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/allowed-attribute", c.HostURL), strings.NewReader(string(rb)))
+	body, err := c.doRequest(req)
 	if err != nil {
 		return nil, err
 	}
+	if body == nil {
+		return nil, nil
+	}
 
-	body, err := c.doRequest(req)
+	attributes := []AttributeRestriction{}
+	err = json.Unmarshal(body, &attributes)
 	if err != nil {
 		return nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	return attributes, nil
+}
+
+func (c *Client) CreateAttributeRestriction(creationRequest AttributeRestriction) (*AttributeRestriction, []string, error) {
+	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/allowed-attribute", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, warnings, err := c.doWriteRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	V, err := unmarshalCreatedID(body)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateAttributeRestriction(attributeID string, creationRequest AttributeRestriction) error {
+func (c *Client) UpdateAttributeRestriction(attributeID string, creationRequest AttributeRestriction) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/allowed-attribute/%s", c.HostURL, attributeID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteAttributeRestriction(attributeID string) error {
@@ -30,49 +30,91 @@ func (c *Client) GetBranchProtection(branchProtectionId string) (*BranchProtecti
 	return &branchProtection, nil
 }
 
-func (c *Client) CreateBranchProtection(creationRequest BranchProtection) (*BranchProtection, error) {
-	rb, err := json.Marshal(creationRequest)
+func (c *Client) ListBranchProtections() ([]BranchProtection, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/branch-protection", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/branch-protection", c.HostURL), strings.NewReader(string(rb)))
+	body, err := c.doRequest(req)
 	if err != nil {
 		return nil, err
 	}
+	if body == nil {
+		return nil, nil
+	}
 
-	body, err := c.doRequest(req)
+	branchProtections := []BranchProtection{}
+	err = json.Unmarshal(body, &branchProtections)
 	if err != nil {
 		return nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	return branchProtections, nil
+}
+
+// Finds the branch protection with exactly the given protection pattern.
+func (c *Client) FindBranchProtection(protectionPattern string) (*BranchProtection, error) {
+	branchProtections, err := c.ListBranchProtections()
 	if err != nil {
 		return nil, err
 	}
 
+	var found *BranchProtection
+	for i := range branchProtections {
+		if branchProtections[i].ProtectionPattern == protectionPattern {
+			if found != nil {
+				return nil, fmt.Errorf("More than one branch protection matches %q, refer to it by ID instead", protectionPattern)
+			}
+			found = &branchProtections[i]
+		}
+	}
+
+	return found, nil
+}
+
+func (c *Client) CreateBranchProtection(creationRequest BranchProtection) (*BranchProtection, []string, error) {
+	rb, err := json.Marshal(creationRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/branch-protection", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, warnings, err := c.doWriteRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	V, err := unmarshalCreatedID(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateBranchProtection(branchProtectionId string, creationRequest BranchProtection) error {
+func (c *Client) UpdateBranchProtection(branchProtectionId string, creationRequest BranchProtection) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/branch-protection/%s", c.HostURL, branchProtectionId), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteBranchProtection(branchProtectionId string) error {
@@ -0,0 +1,110 @@
+package anaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TaggedObject is an object of any type which carries labels and
+// attributes. The rest of the object is kept as the server sent it, so
+// that updating the tags doesn't drop fields the provider doesn't model.
+type TaggedObject struct {
+	ID         int
+	Labels     []string
+	Attributes []Attribute
+	raw        map[string]json.RawMessage
+}
+
+// Lists the objects at the given endpoint, such as "feature-set", which
+// carry every one of the given labels.
+func (c *Client) ListTaggedObjects(endpoint string, labels []string) ([]TaggedObject, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", c.HostURL, endpoint), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	for _, label := range labels {
+		q.Add("label", label)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []map[string]json.RawMessage{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only keep the matching objects, in case the server ignores the filter.
+	res := make([]TaggedObject, 0, len(items))
+	for _, item := range items {
+		object := TaggedObject{raw: item}
+		if err := json.Unmarshal(item["id"], &object.ID); err != nil {
+			return nil, err
+		}
+		if labels, ok := item["labels"]; ok {
+			if err := json.Unmarshal(labels, &object.Labels); err != nil {
+				return nil, err
+			}
+		}
+		if attributes, ok := item["attributes"]; ok {
+			if err := json.Unmarshal(attributes, &object.Attributes); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.hasLabels(object.Labels, labels) {
+			res = append(res, object)
+		}
+	}
+
+	return res, nil
+}
+
+// Sends an object back to the server with its labels and attributes
+// replaced by those on the TaggedObject.
+func (c *Client) UpdateTaggedObject(endpoint string, object TaggedObject) ([]string, error) {
+	labels, err := json.Marshal(object.Labels)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := json.Marshal(object.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string]json.RawMessage, len(object.raw))
+	for k, v := range object.raw {
+		raw[k] = v
+	}
+	raw["labels"] = labels
+	raw["attributes"] = attributes
+
+	rb, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/%s/%d", c.HostURL, endpoint, object.ID), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	_, warnings, err := c.doWriteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return warnings, nil
+}
@@ -2,23 +2,44 @@ package anaml
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // HostURL - Default Anaml URL
 const HostURL string = "http://localhost:8080"
 
+// The User-Agent Go's HTTP client sends, which the provider keeps as the
+// base of its own.
+const baseUserAgent string = "Go-http-client/1.1"
+
 // Client -
 type Client struct {
-	HostURL    string
-	HTTPClient *http.Client
-	Auth       *AuthStruct
-	Branch     *string
+	HostURL               string
+	HTTPClient            *http.Client
+	Auth                  *AuthStruct
+	Branch                *string
+	DefaultLabels         []string
+	DefaultAttributes     map[string]string
+	MaxRetries            int
+	CaseInsensitiveLabels bool
+	ValidateFeatureTables bool
+	DefaultCluster        string
+	APIVersion            string
+	UserAgentSuffix       string
+	// Create each feature with its own request, rather than waiting
+	// briefly to group it with others into a batch.
+	DisableFeatureBatching bool
+
+	featureBatcher *featureBatcher
+	requests       chan struct{}
 }
 
 // AuthStruct -
@@ -35,8 +56,9 @@ type AuthResponse struct {
 // NewClient -
 func NewClient(host, username, password, branch *string, timeout time.Duration) (*Client, error) {
 	c := Client{
-		HTTPClient: &http.Client{Timeout: timeout},
-		HostURL:    HostURL,
+		HTTPClient:     &http.Client{Timeout: timeout},
+		HostURL:        HostURL,
+		featureBatcher: &featureBatcher{},
 	}
 
 	if host != nil {
@@ -56,9 +78,95 @@ func NewClient(host, username, password, branch *string, timeout time.Duration)
 	return &c, nil
 }
 
+// Returns the form of a label used to compare it with others.
+func (c *Client) labelKey(label string) string {
+	if c.CaseInsensitiveLabels {
+		return strings.ToLower(label)
+	}
+	return label
+}
+
+// Whether an object's labels include every one of the wanted labels.
+func (c *Client) hasLabels(carried []string, wanted []string) bool {
+	keys := make(map[string]bool, len(carried))
+	for _, label := range carried {
+		keys[c.labelKey(label)] = true
+	}
+	for _, label := range wanted {
+		if !keys[c.labelKey(label)] {
+			return false
+		}
+	}
+	return true
+}
+
+// SetMaxConcurrentRequests bounds how many HTTP requests the client has
+// in flight at once, whatever Terraform's parallelism. Zero removes the
+// limit.
+func (c *Client) SetMaxConcurrentRequests(max int) {
+	if max > 0 {
+		c.requests = make(chan struct{}, max)
+	} else {
+		c.requests = nil
+	}
+}
+
+// SetConnectionReuse controls how the client reuses connections to the
+// server. Turning keep-alives off opens a new connection per request,
+// which avoids resets from proxies that drop idle connections. A positive
+// maxIdleConns caps how many idle connections are kept open, and zero
+// keeps Go's default.
+func (c *Client) SetConnectionReuse(disableKeepAlives bool, maxIdleConns int) {
+	if !disableKeepAlives && maxIdleConns <= 0 {
+		return
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = disableKeepAlives
+	if maxIdleConns > 0 {
+		transport.MaxIdleConns = maxIdleConns
+		transport.MaxIdleConnsPerHost = maxIdleConns
+	}
+	c.HTTPClient.Transport = transport
+}
+
+// Sends a request, waiting for a free slot first when the number of
+// concurrent requests is limited.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	if c.requests != nil {
+		c.requests <- struct{}{}
+		defer func() { <-c.requests }()
+	}
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, body, nil
+}
+
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	body, _, err := c.doWriteRequest(req)
+	return body, err
+}
+
+// Sends a request like doRequest, also returning the non-fatal warnings,
+// such as the use of a deprecated field, which the server may add to a
+// write's response as a "warnings" array.
+func (c *Client) doWriteRequest(req *http.Request) ([]byte, []string, error) {
 	req.SetBasicAuth(c.Auth.Username, c.Auth.Password)
 	req.Header.Set("Content-Type", "application/json")
+	if c.UserAgentSuffix != "" {
+		req.Header.Set("User-Agent", fmt.Sprintf("%s %s", baseUserAgent, c.UserAgentSuffix))
+	}
+	if c.APIVersion != "" {
+		req.Header.Set("Accept", fmt.Sprintf("application/vnd.anaml.%s+json", c.APIVersion))
+	}
 
 	if c.Branch != nil {
 		q := req.URL.Query()
@@ -68,40 +176,176 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 
 	log.Printf("[DEBUG] Request: %v\n", req)
 
+	var requestBody []byte
 	if req.Body != nil {
-		requestBody, err := ioutil.ReadAll(req.Body)
-		if err == nil {
-			reader0 := ioutil.NopCloser(bytes.NewBuffer(requestBody))
-			reader1 := ioutil.NopCloser(bytes.NewBuffer(requestBody))
-			log.Printf("[DEBUG] Request body: %q", reader0)
-			req.Body = reader1
+		var err error
+		requestBody, err = ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, nil, err
 		}
+		reader0 := ioutil.NopCloser(bytes.NewBuffer(requestBody))
+		log.Printf("[DEBUG] Request body: %q", reader0)
 	}
 
-	res, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
+	var res *http.Response
+	var responseBody []byte
+	for attempt := 0; ; attempt++ {
+		if req.Body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewBuffer(requestBody))
+		}
 
-	log.Printf("[DEBUG] Response: %v\n", res)
+		var err error
+		res, responseBody, err = c.send(req)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	defer res.Body.Close()
+		log.Printf("[DEBUG] Response: %v\n", res)
 
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+		if res.StatusCode != http.StatusTooManyRequests || attempt >= c.MaxRetries {
+			break
+		}
+
+		delay := retryAfter(res, attempt)
+		log.Printf("[DEBUG] Rate limited, retrying in %v", delay)
+		time.Sleep(delay)
 	}
 
 	reader := ioutil.NopCloser(bytes.NewBuffer(responseBody))
 	log.Printf("[DEBUG] Request body: %q", reader)
 
 	if res.StatusCode == 404 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	if res.StatusCode >= 300 {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, responseBody)
+		return nil, nil, newAPIError(res.StatusCode, responseBody)
+	}
+
+	var warnings []string
+	if req.Method != http.MethodGet {
+		warnings = responseWarnings(responseBody)
+	}
+	return responseBody, warnings, nil
+}
+
+// Unmarshals the ID of a created object from the response to a create.
+// The server answers with either the bare ID, or an object holding the ID
+// when the response also carries warnings.
+func unmarshalCreatedID(body []byte) (int, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var response struct {
+			ID *int `json:"id"`
+		}
+		if err := json.Unmarshal(trimmed, &response); err != nil {
+			return 0, err
+		}
+		if response.ID == nil {
+			return 0, fmt.Errorf("Create response has no id: %s", trimmed)
+		}
+		return *response.ID, nil
+	}
+
+	var id int
+	err := json.Unmarshal(trimmed, &id)
+	return id, err
+}
+
+// Picks out the "warnings" array of a response, if it has one.
+func responseWarnings(body []byte) []string {
+	var response struct {
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil
+	}
+	return response.Warnings
+}
+
+// APIError is returned for any response with an error status, other than
+// a 404, which is treated as the object not existing. ErrorCode is the
+// server's code for the error, such as "name-conflict", when the body
+// carries one.
+type APIError struct {
+	StatusCode int
+	ErrorCode  string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("status: %d, code: %s, body: %s", e.StatusCode, e.ErrorCode, e.Message)
 	}
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Message)
+}
+
+// Builds an APIError from an error response. The body is kept as the
+// message, and its error code is picked out if it is a JSON object with
+// a "code" or "errorCode" field.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := APIError{StatusCode: statusCode, Message: string(body)}
+
+	var fields struct {
+		Code      string `json:"code"`
+		ErrorCode string `json:"errorCode"`
+	}
+	if err := json.Unmarshal(body, &fields); err == nil {
+		apiErr.ErrorCode = fields.ErrorCode
+		if apiErr.ErrorCode == "" {
+			apiErr.ErrorCode = fields.Code
+		}
+	}
+	return &apiErr
+}
+
+// Unmarshals the response to a find by name into v, returning false if
+// nothing has the name. The server answers with either the one object of
+// that name or a list of matches. Rather than pick one of several objects
+// sharing a name, which may not be the one intended, this reports them as
+// ambiguous.
+func unmarshalNamed(body []byte, kind string, name string, v interface{}) (bool, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		matches := []json.RawMessage{}
+		if err := json.Unmarshal(trimmed, &matches); err != nil {
+			return false, err
+		}
+		if len(matches) == 0 {
+			return false, nil
+		}
+		if len(matches) > 1 {
+			return false, fmt.Errorf("More than one %s is named %q, refer to it by ID instead", kind, name)
+		}
+		trimmed = matches[0]
+	}
+
+	if err := json.Unmarshal(trimmed, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-	return responseBody, err
+// The longest we will wait before retrying a rate limited request.
+const maxRetryDelay = 60 * time.Second
+
+// Returns how long to wait before retrying a rate limited request. The
+// server's Retry-After header is honoured when present, otherwise the
+// delay doubles with each attempt.
+func retryAfter(res *http.Response, attempt int) time.Duration {
+	delay := time.Second << uint(attempt)
+	if header := res.Header.Get("Retry-After"); header != "" {
+		if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(header); err == nil {
+			delay = time.Until(date)
+		}
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
 }
@@ -0,0 +1,314 @@
+package anaml
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// The client logs every request and response, which would bury the
+// test output.
+func TestMain(m *testing.M) {
+	log.SetOutput(ioutil.Discard)
+	os.Exit(m.Run())
+}
+
+// Returns a client which talks to a server running the given handler,
+// closed when the test ends.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	username, password := "user", "password"
+	c, err := NewClient(&server.URL, &username, &password, nil, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := []struct {
+		name    string
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		{"first attempt without header", "", 0, time.Second},
+		{"third attempt without header", "", 2, 4 * time.Second},
+		{"backoff is capped", "", 10, maxRetryDelay},
+		{"seconds", "3", 5, 3 * time.Second},
+		{"zero seconds", "0", 1, 0},
+		{"seconds are capped", "3600", 0, maxRetryDelay},
+		{"negative seconds fall back to backoff", "-1", 1, 2 * time.Second},
+		{"date in the past", "Wed, 21 Oct 2015 07:28:00 GMT", 0, 0},
+		{"unparseable header falls back to backoff", "soon", 1, 2 * time.Second},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				res.Header.Set("Retry-After", tt.header)
+			}
+			if got := retryAfter(res, tt.attempt); got != tt.want {
+				t.Errorf("retryAfter(%q, %d) = %v, want %v", tt.header, tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoRequestRetriesRateLimited(t *testing.T) {
+	cases := []struct {
+		name         string
+		maxRetries   int
+		limited      int
+		wantRequests int
+		wantStatus   int
+	}{
+		{"not limited", 2, 0, 1, 0},
+		{"succeeds on retry", 2, 2, 3, 0},
+		{"retries exhausted", 1, 2, 2, http.StatusTooManyRequests},
+		{"retries disabled", 0, 1, 1, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if body, _ := ioutil.ReadAll(r.Body); string(body) != `{"name":"customer"}` {
+					t.Errorf("attempt %d sent body %q", requests, body)
+				}
+				if requests <= tt.limited {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.Write([]byte(`1`))
+			})
+			c.MaxRetries = tt.maxRetries
+
+			req, _ := http.NewRequest("POST", c.HostURL+"/entity", strings.NewReader(`{"name":"customer"}`))
+			_, err := c.doRequest(req)
+
+			if requests != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", requests, tt.wantRequests)
+			}
+			var apiErr *APIError
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Errorf("err = %v, want none", err)
+			case tt.wantStatus != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus):
+				t.Errorf("err = %v, want a %d APIError", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	cases := []struct {
+		name    string
+		max     int
+		wantMax int32
+	}{
+		{"one at a time", 1, 1},
+		{"limited", 3, 3},
+		{"unlimited", 0, 10},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, peak int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				w.Write([]byte(`{}`))
+			})
+			c.SetMaxConcurrentRequests(tt.max)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					req, _ := http.NewRequest("GET", c.HostURL+"/entity/1", nil)
+					if _, err := c.doRequest(req); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if peak > tt.wantMax {
+				t.Errorf("%d requests were in flight at once, want at most %d", peak, tt.wantMax)
+			}
+			if tt.max == 0 && peak < 2 {
+				t.Errorf("%d requests were in flight at once, want them sent concurrently", peak)
+			}
+		})
+	}
+}
+
+func TestUnmarshalNamed(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		wantFound bool
+		wantID    int
+		wantErr   string
+	}{
+		{"single object", `{"id": 3, "name": "customer"}`, true, 3, ""},
+		{"single object with whitespace", "\n  {\"id\": 3, \"name\": \"customer\"}", true, 3, ""},
+		{"list of one", `[{"id": 4, "name": "customer"}]`, true, 4, ""},
+		{"empty list", `[]`, false, 0, ""},
+		{"empty list with whitespace", " [ ] ", false, 0, ""},
+		{"list of two", `[{"id": 4, "name": "customer"}, {"id": 5, "name": "customer"}]`, false, 0, `More than one entity is named "customer", refer to it by ID instead`},
+		{"malformed list", `[{"id": 4`, false, 0, "unexpected end of JSON input"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := Entity{}
+			found, err := unmarshalNamed([]byte(tt.body), "entity", "customer", &entity)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("err = %v, want none", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if found != tt.wantFound {
+				t.Errorf("found = %v, want %v", found, tt.wantFound)
+			}
+			if found && entity.ID != tt.wantID {
+				t.Errorf("id = %d, want %d", entity.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestFindByNameAmbiguous(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": 1, "name": "shared", "protectionPattern": "shared"}, {"id": 2, "name": "shared", "protectionPattern": "shared"}]`))
+	})
+
+	finds := []struct {
+		name string
+		find func() error
+	}{
+		{"source", func() error { _, err := c.FindSource("shared"); return err }},
+		{"destination", func() error { _, err := c.FindDestination("shared"); return err }},
+		{"cluster", func() error { _, err := c.FindCluster("shared"); return err }},
+		{"entity", func() error { _, err := c.FindEntityByName("shared"); return err }},
+		{"entity population", func() error { _, err := c.FindEntityPopulationByName("shared"); return err }},
+		{"event store", func() error { _, err := c.FindEventStoreByName("shared"); return err }},
+		{"feature", func() error { _, err := c.FindFeatureByName("shared"); return err }},
+		{"feature template", func() error { _, err := c.FindFeatureTemplateByName("shared"); return err }},
+		{"feature set", func() error { _, err := c.FindFeatureSetByName("shared"); return err }},
+		{"feature store", func() error { _, err := c.FindFeatureStoreByName("shared"); return err }},
+		{"table", func() error { _, err := c.FindTableByName("shared"); return err }},
+		{"user", func() error { _, err := c.FindUser("shared"); return err }},
+		{"user group", func() error { _, err := c.FindUserGroup("shared"); return err }},
+		{"view materialisation job", func() error { _, err := c.FindViewMaterialisationJobByName("shared"); return err }},
+		{"spark property bundle", func() error { _, err := c.FindSparkPropertyBundle("shared"); return err }},
+		{"branch protection", func() error { _, err := c.FindBranchProtection("shared"); return err }},
+		{"webhook", func() error { _, err := c.FindWebhook("shared"); return err }},
+	}
+
+	for _, tt := range finds {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.find()
+			if err == nil || !strings.HasPrefix(err.Error(), "More than one") || !strings.Contains(err.Error(), "refer to it by ID instead") {
+				t.Errorf("err = %v, want an ambiguous match", err)
+			}
+		})
+	}
+}
+
+func TestHasLabels(t *testing.T) {
+	cases := []struct {
+		name            string
+		caseInsensitive bool
+		carried, wanted []string
+		want            bool
+	}{
+		{"no filter", false, []string{"pii"}, nil, true},
+		{"no filter or labels", false, nil, nil, true},
+		{"one of one", false, []string{"pii"}, []string{"pii"}, true},
+		{"subset", false, []string{"pii", "finance", "daily"}, []string{"daily", "pii"}, true},
+		{"missing one", false, []string{"pii"}, []string{"pii", "finance"}, false},
+		{"no labels carried", false, nil, []string{"pii"}, false},
+		{"case differs", false, []string{"PII"}, []string{"pii"}, false},
+		{"case differs, case insensitive", true, []string{"PII"}, []string{"pii"}, true},
+		{"missing one, case insensitive", true, []string{"PII"}, []string{"pii", "finance"}, false},
+		{"mixed case, case insensitive", true, []string{"Pii", "FINANCE"}, []string{"pII", "Finance"}, true},
+		{"mixed case", false, []string{"Pii", "FINANCE"}, []string{"Pii", "Finance"}, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{CaseInsensitiveLabels: tt.caseInsensitive}
+			if got := c.hasLabels(tt.carried, tt.wanted); got != tt.want {
+				t.Errorf("hasLabels(%v, %v) = %v, want %v", tt.carried, tt.wanted, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListEntitiesByLabels(t *testing.T) {
+	cases := []struct {
+		name            string
+		caseInsensitive bool
+		labels          []string
+		wantQuery       string
+		want            []int
+	}{
+		{"exact", false, []string{"Customer"}, "label=Customer", []int{1}},
+		{"case differs", false, []string{"customer"}, "label=customer", []int{}},
+		{"case differs, case insensitive", true, []string{"cUsToMeR"}, "", []int{1, 2}},
+		{"two labels, case insensitive", true, []string{"customer", "PII"}, "", []int{2}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+				w.Write([]byte(`[{"id": 1, "labels": ["Customer"]}, {"id": 2, "labels": ["CUSTOMER", "pii"]}, {"id": 3, "labels": ["order"]}]`))
+			})
+			c.CaseInsensitiveLabels = tt.caseInsensitive
+
+			entities, err := c.ListEntitiesByLabels(tt.labels)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.wantQuery {
+				t.Errorf("query = %q, want %q", query, tt.wantQuery)
+			}
+			got := []int{}
+			for _, entity := range entities {
+				got = append(got, entity.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("entities = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
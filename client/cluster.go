@@ -30,49 +30,48 @@ func (c *Client) GetCluster(clusterID string) (*Cluster, error) {
 	return &cluster, nil
 }
 
-func (c *Client) CreateCluster(creationRequest Cluster) (*Cluster, error) {
+func (c *Client) CreateCluster(creationRequest Cluster) (*Cluster, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/cluster", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateCluster(clusterID string, creationRequest Cluster) error {
+func (c *Client) UpdateCluster(clusterID string, creationRequest Cluster) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/cluster/%s", c.HostURL, clusterID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteCluster(clusterID string) error {
@@ -0,0 +1,77 @@
+package anaml
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceAccessToken() *schema.Resource {
+	return &schema.Resource{
+		Description: "The metadata of an Access Token. The token's secret is never returned.",
+
+		ReadContext: withDiagnostics(dataSourceAccessTokenRead),
+
+		Schema: map[string]*schema.Schema{
+			"token_id": {
+				Type:        schema.TypeString,
+				Description: "The Access Token's identifier",
+				Required:    true,
+			},
+			"owner": {
+				Type:         schema.TypeString,
+				Description:  "The identifier of the User which owns the Access Token",
+				Required:     true,
+				ValidateFunc: validateAnamlIdentifier(),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"projects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAccessTokenRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	tokenID := d.Get("token_id").(string)
+	owner, _ := strconv.Atoi(d.Get("owner").(string))
+
+	token, err := c.GetAccessToken(owner, tokenID)
+	if err != nil {
+		return err
+	}
+
+	if token == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(token.ID)
+
+	if token.Owner != nil {
+		if err := d.Set("owner", strconv.Itoa(*token.Owner)); err != nil {
+			return err
+		}
+	}
+	if err := d.Set("description", token.Description); err != nil {
+		return err
+	}
+	if err := d.Set("roles", mapRolesToFrontend(token.Roles)); err != nil {
+		return err
+	}
+	if err := d.Set("projects", identifierList(token.Projects)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package anaml
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceAccessTokens() *schema.Resource {
+	return &schema.Resource{
+		Description: "The metadata of existing Access Tokens. Token secrets are never returned.",
+
+		ReadContext: withDiagnostics(dataSourceAccessTokensRead),
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:         schema.TypeString,
+				Description:  "Only list the Access Tokens of this User, given by name or identifier",
+				Optional:     true,
+				ValidateFunc: validateOwner(),
+			},
+			"access_tokens": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Access Tokens",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"roles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"projects": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAccessTokensRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+
+	var owner *int
+	if ownerRef := d.Get("owner").(string); ownerRef != "" {
+		ownerID, err := expandOwner(c, ownerRef)
+		if err != nil {
+			return err
+		}
+		owner = &ownerID
+	}
+
+	tokens, err := c.ListAccessTokens(owner)
+	if err != nil {
+		return err
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(tokens))
+	for _, token := range tokens {
+		single := map[string]interface{}{
+			"token_id":    token.ID,
+			"description": token.Description,
+			"roles":       mapRolesToFrontend(token.Roles),
+			"projects":    identifierList(token.Projects),
+		}
+		if token.Owner != nil {
+			single["owner"] = strconv.Itoa(*token.Owner)
+		} else if owner != nil {
+			single["owner"] = strconv.Itoa(*owner)
+		}
+		flattened = append(flattened, single)
+	}
+
+	if owner != nil {
+		d.SetId(strconv.Itoa(*owner))
+	} else {
+		d.SetId("all")
+	}
+	if err := d.Set("access_tokens", flattened); err != nil {
+		return err
+	}
+	return nil
+}
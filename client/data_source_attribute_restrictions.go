@@ -0,0 +1,125 @@
+package anaml
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceAttributeRestrictions() *schema.Resource {
+	return &schema.Resource{
+		Description: "All Attribute Restrictions",
+
+		ReadContext: withWarnings(dataSourceAttributeRestrictionsRead),
+
+		Schema: map[string]*schema.Schema{
+			"attribute_restrictions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The attributes which may be applied to objects",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One of enum, freetext, boolean, integer, user or user_group",
+						},
+						"mandatory": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"default_value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"choice": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The values an enum attribute may take",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"display_emoji": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"display_colour": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"applies_to": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAttributeRestrictionsRead(d *schema.ResourceData, m interface{}) ([]string, error) {
+	c := m.(*Client)
+
+	attributes, err := c.ListAttributeRestrictions()
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	flattened := make([]map[string]interface{}, 0, len(attributes))
+	for _, attribute := range attributes {
+		single := make(map[string]interface{})
+		single["id"] = strconv.Itoa(attribute.ID)
+		single["key"] = attribute.Key
+		single["description"] = attribute.Description
+		single["type"] = attributeTypeToFrontend(attribute.Type)
+		single["mandatory"] = attribute.Mandatory
+		if attribute.DefaultValue != nil {
+			single["default_value"] = *attribute.DefaultValue
+		}
+		if attribute.Choices != nil {
+			single["choice"] = flattenEnumChoices(*attribute.Choices)
+		}
+		appliesTo, unknown := mapTargetsToFrontend(attribute.AppliesTo)
+		warnings = append(warnings, unknownTargetWarnings(attribute.Key, unknown)...)
+		single["applies_to"] = appliesTo
+		flattened = append(flattened, single)
+	}
+
+	d.SetId("all")
+	if err := d.Set("attribute_restrictions", flattened); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+// Maps a backend attribute type, such as "usergroupattribute", to the
+// name of the block used to declare it on the attribute restriction
+// resource, such as "user_group".
+func attributeTypeToFrontend(backend string) string {
+	if backend == "usergroupattribute" {
+		return "user_group"
+	}
+	return strings.TrimSuffix(backend, "attribute")
+}
@@ -0,0 +1,93 @@
+package anaml
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceBranchProtection() *schema.Resource {
+	return &schema.Resource{
+		Description: "A Branch Protection, looked up by its protection pattern",
+
+		ReadContext: withDiagnostics(dataSourceBranchProtectionRead),
+
+		Schema: branchProtectionDataSchema(map[string]*schema.Schema{
+			"protection_pattern": {
+				Type:        schema.TypeString,
+				Description: "The pattern of the branches the Branch Protection applies to.",
+				Required:    true,
+			},
+		}),
+	}
+}
+
+// Adds the computed fields of a Branch Protection to a data source schema.
+func branchProtectionDataSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["merge_approval_rules"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Rules which must be satisfied before a change request can be merged.",
+		Computed:    true,
+		Elem:        approvalRuleSchema(),
+	}
+	s["push_whitelist"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Principals which can push directly to matching branches.",
+		Computed:    true,
+		Elem:        principalIdSchema(),
+	}
+	s["apply_to_admins"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether administrators are forbidden from pushing directly to matching branches.",
+		Computed:    true,
+	}
+	s["allow_branch_deletion"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether matching branches can be deleted.",
+		Computed:    true,
+	}
+	return s
+}
+
+func dataSourceBranchProtectionRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	protectionPattern := d.Get("protection_pattern").(string)
+
+	branchProtection, err := c.FindBranchProtection(protectionPattern)
+	if err != nil {
+		return err
+	}
+	if branchProtection == nil {
+		d.SetId("")
+		return nil
+	}
+
+	flattened, err := flattenBranchProtection(*branchProtection)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(branchProtection.ID))
+	for _, key := range []string{"merge_approval_rules", "push_whitelist", "apply_to_admins", "allow_branch_deletion"} {
+		if err := d.Set(key, flattened[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flattenBranchProtection(branchProtection BranchProtection) (map[string]interface{}, error) {
+	approvalRules, err := flattenApprovalRules(branchProtection.MergeApprovalRules)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"id":                    strconv.Itoa(branchProtection.ID),
+		"protection_pattern":    branchProtection.ProtectionPattern,
+		"merge_approval_rules":  approvalRules,
+		"push_whitelist":        flattenPrincipalIds(branchProtection.PushWhitelist),
+		"apply_to_admins":       branchProtection.ApplyToAdmins,
+		"allow_branch_deletion": branchProtection.AllowBranchDeletion,
+	}, nil
+}
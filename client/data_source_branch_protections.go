@@ -0,0 +1,57 @@
+package anaml
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceBranchProtections() *schema.Resource {
+	return &schema.Resource{
+		Description: "All Branch Protections",
+
+		ReadContext: withDiagnostics(dataSourceBranchProtectionsRead),
+
+		Schema: map[string]*schema.Schema{
+			"branch_protections": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Branch Protections",
+				Elem: &schema.Resource{
+					Schema: branchProtectionDataSchema(map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protection_pattern": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBranchProtectionsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+
+	branchProtections, err := c.ListBranchProtections()
+	if err != nil {
+		return err
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(branchProtections))
+	for _, branchProtection := range branchProtections {
+		single, err := flattenBranchProtection(branchProtection)
+		if err != nil {
+			return err
+		}
+		flattened = append(flattened, single)
+	}
+
+	d.SetId("all")
+	if err := d.Set("branch_protections", flattened); err != nil {
+		return err
+	}
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 
 func DataSourceCluster() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceClusterRead,
+		ReadContext: withDiagnostics(dataSourceClusterRead),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -22,6 +22,33 @@ func DataSourceCluster() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"is_preview_cluster": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster is used for preview generation",
+			},
+			"spark_server_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the Spark Server, for a remote cluster",
+			},
+			"anaml_server_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the Anaml Server, for a local cluster",
+			},
+			"labels": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Labels attached to the cluster",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"attribute": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Attributes (key value pairs) attached to the cluster",
+				Elem:        attributeSchema(),
+			},
 		},
 	}
 }
@@ -47,5 +74,20 @@ func dataSourceClusterRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("description", cluster.Description); err != nil {
 		return err
 	}
+	if err := d.Set("is_preview_cluster", cluster.IsPreviewCluster); err != nil {
+		return err
+	}
+	if err := d.Set("spark_server_url", cluster.SparkServerURL); err != nil {
+		return err
+	}
+	if err := d.Set("anaml_server_url", cluster.AnamlServerURL); err != nil {
+		return err
+	}
+	if err := d.Set("labels", cluster.Labels); err != nil {
+		return err
+	}
+	if err := d.Set("attribute", flattenAttributes(cluster.Attributes)); err != nil {
+		return err
+	}
 	return err
 }
@@ -8,7 +8,7 @@ import (
 
 func DataSourceDestination() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceDestinationRead,
+		ReadContext: withDiagnostics(dataSourceDestinationRead),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -8,7 +8,7 @@ import (
 
 func DataSourceEntity() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTableRead,
+		ReadContext: withDiagnostics(dataSourceTableRead),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -8,7 +8,7 @@ import (
 
 func DataSourceEntityPopulation() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceEntityPopulationRead,
+		ReadContext: withDiagnostics(dataSourceEntityPopulationRead),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -22,6 +22,17 @@ func DataSourceEntityPopulation() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"entity": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Entity the population is made of",
+			},
+			"sources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Sources the population is drawn from",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -48,5 +59,11 @@ func dataSourceEntityPopulationRead(d *schema.ResourceData, m interface{}) error
 	if err := d.Set("description", population.Description); err != nil {
 		return err
 	}
+	if err := d.Set("entity", strconv.Itoa(population.Entity)); err != nil {
+		return err
+	}
+	if err := d.Set("sources", identifierList(population.Sources)); err != nil {
+		return err
+	}
 	return nil
 }
@@ -10,7 +10,7 @@ func DataSourceFeature() *schema.Resource {
 	return &schema.Resource{
 		Description: "A single Feature",
 
-		Read: dataSourceFeatureRead,
+		ReadContext: withDiagnostics(dataSourceFeatureRead),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -0,0 +1,62 @@
+package anaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceFeatureConfigJSON() *schema.Resource {
+	return &schema.Resource{
+		Description: "A Feature's configuration as held by the server, as JSON",
+
+		ReadContext: withDiagnostics(dataSourceFeatureConfigJSONRead),
+
+		Schema: map[string]*schema.Schema{
+			"feature": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The Feature's ID or name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Feature as returned by the API",
+			},
+		},
+	}
+}
+
+func dataSourceFeatureConfigJSONRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	ref := d.Get("feature").(string)
+
+	var feature *Feature
+	var err error
+	if identifierPattern.MatchString(ref) {
+		feature, err = c.GetFeature(ref)
+	} else {
+		feature, err = c.FindFeatureByName(ref)
+	}
+	if err != nil {
+		return err
+	}
+	if feature == nil {
+		return fmt.Errorf("Feature %s not found", ref)
+	}
+
+	config, err := json.Marshal(feature)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(feature.ID))
+	if err := d.Set("json", string(config)); err != nil {
+		return err
+	}
+	return nil
+}
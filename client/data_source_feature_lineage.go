@@ -0,0 +1,72 @@
+package anaml
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceFeatureLineage() *schema.Resource {
+	return &schema.Resource{
+		Description: "The feature sets and tables which depend on a Feature, for checking the impact of changing it",
+
+		ReadContext: withDiagnostics(dataSourceFeatureLineageRead),
+
+		Schema: map[string]*schema.Schema{
+			"feature": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The Feature's ID or name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"feature_sets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the feature sets which include the Feature",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"tables": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the tables downstream of the Feature",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceFeatureLineageRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	ref := d.Get("feature").(string)
+
+	featureID := ref
+	if !identifierPattern.MatchString(ref) {
+		feature, err := c.FindFeatureByName(ref)
+		if err != nil {
+			return err
+		}
+		if feature == nil {
+			return fmt.Errorf("Feature %s not found", ref)
+		}
+		featureID = strconv.Itoa(feature.ID)
+	}
+
+	lineage, err := c.GetFeatureLineage(featureID)
+	if err != nil {
+		return err
+	}
+	if lineage == nil {
+		return fmt.Errorf("Feature %s not found", ref)
+	}
+
+	d.SetId(featureID)
+	if err := d.Set("feature_sets", identifierList(lineage.FeatureSets)); err != nil {
+		return err
+	}
+	if err := d.Set("tables", identifierList(lineage.Tables)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package anaml
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceFeatureSets() *schema.Resource {
+	return &schema.Resource{
+		Description: "The feature sets for an Entity",
+
+		ReadContext: withDiagnostics(dataSourceFeatureSetsRead),
+
+		Schema: map[string]*schema.Schema{
+			"entity": {
+				Type:        schema.TypeString,
+				Description: "The Entity's name or identifier",
+				Required:    true,
+			},
+			"labels": labelFilterSchema(),
+			"feature_sets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Entity's feature sets",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFeatureSetsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	entityRef := d.Get("entity").(string)
+
+	entityID, err := strconv.Atoi(entityRef)
+	if err != nil {
+		entity, err := c.FindEntityByName(entityRef)
+		if err != nil {
+			return err
+		}
+		if entity == nil {
+			return fmt.Errorf("Entity %s not found", entityRef)
+		}
+		entityID = entity.ID
+	}
+
+	featureSets, err := c.ListFeatureSetsByEntity(entityID)
+	if err != nil {
+		return err
+	}
+
+	labels := expandStringList(d.Get("labels").(*schema.Set).List())
+	flattened := make([]map[string]interface{}, 0, len(featureSets))
+	for _, featureSet := range featureSets {
+		if !c.hasLabels(featureSet.Labels, labels) {
+			continue
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"id":   strconv.Itoa(featureSet.ID),
+			"name": featureSet.Name,
+		})
+	}
+
+	d.SetId(strconv.Itoa(entityID))
+	if err := d.Set("feature_sets", flattened); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFeatureSetsLabelFilter(t *testing.T) {
+	cases := []struct {
+		name   string
+		labels []interface{}
+		want   []string
+	}{
+		{"no filter", nil, []string{"daily", "finance", "unlabelled"}},
+		{"one label", []interface{}{"pii"}, []string{"daily", "finance"}},
+		{"every label", []interface{}{"pii", "finance"}, []string{"finance"}},
+		{"no matches", []interface{}{"marketing"}, []string{}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`[
+					{"id": 1, "name": "daily", "entity": 2, "labels": ["pii"]},
+					{"id": 2, "name": "finance", "entity": 2, "labels": ["pii", "finance"]},
+					{"id": 3, "name": "unlabelled", "entity": 2, "labels": []}
+				]`))
+			})
+
+			config := map[string]interface{}{"entity": "2"}
+			if tt.labels != nil {
+				config["labels"] = tt.labels
+			}
+			d := schema.TestResourceDataRaw(t, DataSourceFeatureSets().Schema, config)
+			if diags := DataSourceFeatureSets().ReadContext(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("diags = %v", diags)
+			}
+
+			got := d.Get("feature_sets").([]interface{})
+			if len(got) != len(tt.want) {
+				t.Fatalf("feature_sets = %v, want %v", got, tt.want)
+			}
+			for i, name := range tt.want {
+				if got[i].(map[string]interface{})["name"] != name {
+					t.Errorf("feature_sets[%d] = %v, want %s", i, got[i], name)
+				}
+			}
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 
 func DataSourceFeatureStore() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTableRead,
+		ReadContext: withDiagnostics(dataSourceTableRead),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -8,7 +8,7 @@ import (
 
 func DataSourceFeatureTemplate() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceFeatureRead,
+		ReadContext: withDiagnostics(dataSourceFeatureRead),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -0,0 +1,112 @@
+package anaml
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceInventory() *schema.Resource {
+	objectTypes := make([]string, 0, len(taggableEndpoints))
+	for objectType := range taggableEndpoints {
+		objectTypes = append(objectTypes, objectType)
+	}
+	sort.Strings(objectTypes)
+
+	return &schema.Resource{
+		Description: "The ID and name of every object of the given types. Each type is a full listing from the server, so only ask for the types needed",
+
+		ReadContext: withDiagnostics(dataSourceInventoryRead),
+
+		Schema: map[string]*schema.Schema{
+			"object_types": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The types of object to list, any of: " + strings.Join(objectTypes, ", "),
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(objectTypes, false),
+				},
+			},
+			"objects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The objects found, ordered by type and then ID",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"unavailable_object_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The requested types which the server couldn't list, and which are missing from objects",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceInventoryRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+
+	objectTypes := expandStringList(d.Get("object_types").(*schema.Set).List())
+	sort.Strings(objectTypes)
+
+	flattened := make([]map[string]interface{}, 0)
+	unavailable := make([]string, 0)
+	for _, objectType := range objectTypes {
+		objects, err := c.ListObjects(taggableEndpoints[objectType])
+		// A 404, which the client returns as no objects, or a 405 means
+		// the server has no listing for the type. Any other error, such
+		// as a lack of permission, fails the read.
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusMethodNotAllowed {
+			unavailable = append(unavailable, objectType)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if objects == nil {
+			unavailable = append(unavailable, objectType)
+			continue
+		}
+
+		sort.Slice(objects, func(i, j int) bool { return objects[i].ID < objects[j].ID })
+		for _, object := range objects {
+			flattened = append(flattened, map[string]interface{}{
+				"object_type": objectType,
+				"id":          strconv.Itoa(object.ID),
+				"name":        object.Name,
+			})
+		}
+	}
+
+	d.SetId(strings.Join(objectTypes, ","))
+	if err := d.Set("objects", flattened); err != nil {
+		return err
+	}
+	if err := d.Set("unavailable_object_types", unavailable); err != nil {
+		return err
+	}
+	return nil
+}
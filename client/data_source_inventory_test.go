@@ -0,0 +1,61 @@
+package anaml
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceInventoryReadUnavailableTypes(t *testing.T) {
+	statuses := map[string]int{
+		"/entity":  http.StatusNotFound,
+		"/cluster": http.StatusMethodNotAllowed,
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if status, ok := statuses[r.URL.Path]; ok {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`[{"id": 2, "name": "b"}, {"id": 1, "name": "a"}]`))
+	})
+
+	d := schema.TestResourceDataRaw(t, DataSourceInventory().Schema, map[string]interface{}{
+		"object_types": []interface{}{"cluster", "entity", "feature"},
+	})
+	if err := dataSourceInventoryRead(d, c); err != nil {
+		t.Fatal(err)
+	}
+
+	unavailable := d.Get("unavailable_object_types").([]interface{})
+	if want := []interface{}{"cluster", "entity"}; !reflect.DeepEqual(unavailable, want) {
+		t.Errorf("unavailable_object_types = %v, want %v", unavailable, want)
+	}
+	if got := d.Get("objects.#"); got != 2 {
+		t.Fatalf("objects.# = %v, want 2", got)
+	}
+	if got := d.Get("objects.0.name"); got != "a" {
+		t.Errorf("objects.0.name = %v, want a", got)
+	}
+}
+
+func TestDataSourceInventoryReadFailsOnOtherErrors(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError, http.StatusBadGateway} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+			})
+
+			d := schema.TestResourceDataRaw(t, DataSourceInventory().Schema, map[string]interface{}{
+				"object_types": []interface{}{"feature"},
+			})
+			err := dataSourceInventoryRead(d, c)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != status {
+				t.Fatalf("err = %v, want an APIError with status %d", err, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package anaml
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceLabelRestrictions() *schema.Resource {
+	return &schema.Resource{
+		Description: "All Label Restrictions",
+
+		ReadContext: withDiagnostics(dataSourceLabelRestrictionsRead),
+
+		Schema: map[string]*schema.Schema{
+			"label_restrictions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The labels which may be applied to objects",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"text": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"emoji": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"colour": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLabelRestrictionsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+
+	labels, err := c.ListLabelRestrictions()
+	if err != nil {
+		return err
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(labels))
+	for _, label := range labels {
+		single := make(map[string]interface{})
+		single["id"] = strconv.Itoa(label.ID)
+		single["text"] = label.Text
+		if label.Emoji != nil {
+			single["emoji"] = *label.Emoji
+		}
+		if label.Colour != nil {
+			single["colour"] = *label.Colour
+		}
+		flattened = append(flattened, single)
+	}
+
+	d.SetId("all")
+	if err := d.Set("label_restrictions", flattened); err != nil {
+		return err
+	}
+	return nil
+}
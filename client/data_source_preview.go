@@ -0,0 +1,69 @@
+package anaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourcePreview() *schema.Resource {
+	return &schema.Resource{
+		Description: "Sample values of a Feature Set, computed for a few entities without running a Feature Store",
+
+		ReadContext: withDiagnostics(dataSourcePreviewRead),
+
+		Schema: map[string]*schema.Schema{
+			"feature_set": {
+				Type:         schema.TypeString,
+				Description:  "The Feature Set to preview",
+				Required:     true,
+				ValidateFunc: validateAnamlIdentifier(),
+			},
+			"entities": {
+				Type:        schema.TypeList,
+				Description: "The entity keys to compute the features for",
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    100,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"rows": {
+				Type:        schema.TypeString,
+				Description: "The computed rows, encoded as a JSON list of objects",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourcePreviewRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	featureSetID, _ := strconv.Atoi(d.Get("feature_set").(string))
+	entities := expandStringList(d.Get("entities").([]interface{}))
+
+	rows, err := c.PreviewFeatures(featureSetID, entities)
+	if err != nil {
+		return err
+	}
+	if rows == nil {
+		return fmt.Errorf("Feature Set %d not found", featureSetID)
+	}
+
+	encoded, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", featureSetID, strings.Join(entities, ",")))
+	if err := d.Set("rows", string(encoded)); err != nil {
+		return err
+	}
+	return nil
+}
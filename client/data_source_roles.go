@@ -0,0 +1,32 @@
+package anaml
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceRoles() *schema.Resource {
+	return &schema.Resource{
+		Description: "The roles which may be granted to users and user groups",
+
+		ReadContext: withDiagnostics(dataSourceRolesRead),
+
+		Schema: map[string]*schema.Schema{
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the roles",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// The server has no endpoint listing roles, so these are the roles the
+// provider itself accepts.
+func dataSourceRolesRead(d *schema.ResourceData, m interface{}) error {
+	d.SetId("all")
+	if err := d.Set("roles", validRoles()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 
 func DataSourceSource() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceSourceRead,
+		ReadContext: withDiagnostics(dataSourceSourceRead),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
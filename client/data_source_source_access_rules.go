@@ -0,0 +1,71 @@
+package anaml
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceSourceAccessRules() *schema.Resource {
+	return &schema.Resource{
+		Description: "The access rules attached to a Source",
+
+		ReadContext: withDiagnostics(dataSourceSourceAccessRulesRead),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The Source's name",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "source_id"},
+			},
+			"source_id": {
+				Type:         schema.TypeString,
+				Description:  "The Source's identifier",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAnamlIdentifier(),
+			},
+			"access_rule": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Access rules attached to the Source",
+				Elem:        accessRuleSchema(),
+			},
+		},
+	}
+}
+
+func dataSourceSourceAccessRulesRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+
+	var source *Source
+	var err error
+	if sourceID, ok := d.GetOk("source_id"); ok {
+		source, err = c.GetSource(sourceID.(string))
+	} else {
+		source, err = c.FindSource(d.Get("name").(string))
+	}
+	if err != nil {
+		return err
+	}
+
+	if source == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(strconv.Itoa(source.ID))
+
+	if err := d.Set("name", source.Name); err != nil {
+		return err
+	}
+	if err := d.Set("source_id", strconv.Itoa(source.ID)); err != nil {
+		return err
+	}
+	if err := d.Set("access_rule", flattenAccessRules(source.AccessRules)); err != nil {
+		return err
+	}
+	return nil
+}
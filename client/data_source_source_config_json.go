@@ -0,0 +1,63 @@
+package anaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceSourceConfigJSON() *schema.Resource {
+	return &schema.Resource{
+		Description: "A Source's configuration as held by the server, as JSON",
+
+		ReadContext: withDiagnostics(dataSourceSourceConfigJSONRead),
+
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The Source's ID or name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The Source as returned by the API, which may include credentials",
+			},
+		},
+	}
+}
+
+func dataSourceSourceConfigJSONRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	ref := d.Get("source").(string)
+
+	var source *Source
+	var err error
+	if identifierPattern.MatchString(ref) {
+		source, err = c.GetSource(ref)
+	} else {
+		source, err = c.FindSource(ref)
+	}
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return fmt.Errorf("Source %s not found", ref)
+	}
+
+	config, err := json.Marshal(source)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(source.ID))
+	if err := d.Set("json", string(config)); err != nil {
+		return err
+	}
+	return nil
+}
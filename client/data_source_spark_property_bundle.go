@@ -0,0 +1,57 @@
+package anaml
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceSparkPropertyBundle() *schema.Resource {
+	return &schema.Resource{
+		Description: "A Spark Property Bundle, looked up by name",
+
+		ReadContext: withDiagnostics(dataSourceSparkPropertyBundleRead),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"additional_spark_properties": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSparkPropertyBundleRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	name := d.Get("name").(string)
+
+	bundle, err := c.FindSparkPropertyBundle(name)
+	if err != nil {
+		return err
+	}
+	if bundle == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(strconv.Itoa(bundle.ID))
+
+	if err := d.Set("description", bundle.Description); err != nil {
+		return err
+	}
+	if err := d.Set("additional_spark_properties", bundle.AdditionalSparkProperties); err != nil {
+		return err
+	}
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 
 func DataSourceTable() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTableRead,
+		ReadContext: withDiagnostics(dataSourceTableRead),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -22,6 +22,17 @@ func DataSourceTable() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The kind of table, such as root, view, pivot or eventstore.",
+			},
+			"event": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The entities and timestamp associated with the table.",
+				Elem:        eventSchema(),
+			},
 		},
 	}
 }
@@ -48,5 +59,11 @@ func dataSourceTableRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("description", feature.Description); err != nil {
 		return err
 	}
+	if err := d.Set("type", feature.Type); err != nil {
+		return err
+	}
+	if err := d.Set("event", flattenEntityDescription(feature.EventInfo)); err != nil {
+		return err
+	}
 	return nil
 }
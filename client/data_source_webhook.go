@@ -0,0 +1,109 @@
+package anaml
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceWebhook() *schema.Resource {
+	return &schema.Resource{
+		Description: "A Webhook, looked up by its name",
+
+		ReadContext: withDiagnostics(dataSourceWebhookRead),
+
+		Schema: webhookDataSchema(map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The Webhook's name.",
+				Required:    true,
+			},
+		}),
+	}
+}
+
+// Adds the computed fields of a Webhook to a data source schema. Headers
+// are left out, as they may carry credentials for the endpoint.
+func webhookDataSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["description"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["url"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The endpoint events are sent to.",
+		Computed:    true,
+	}
+	s["content_type"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The Content-Type the webhook payload is sent with.",
+		Computed:    true,
+	}
+	s["events"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The events the Webhook is subscribed to, named as the blocks of the webhook resource, such as feature_store_runs.",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	return s
+}
+
+func dataSourceWebhookRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	name := d.Get("name").(string)
+
+	webhook, err := c.FindWebhook(name)
+	if err != nil {
+		return err
+	}
+	if webhook == nil {
+		d.SetId("")
+		return nil
+	}
+
+	flattened := flattenWebhook(*webhook)
+
+	d.SetId(strconv.Itoa(webhook.ID))
+	for _, key := range []string{"description", "url", "content_type", "events"} {
+		if err := d.Set(key, flattened[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flattenWebhook(webhook Webhook) map[string]interface{} {
+	subscriptions := []struct {
+		event      string
+		subscribed *struct{}
+	}{
+		{"merge_requests", webhook.MergeRequests},
+		{"merge_request_comments", webhook.MergeRequestComments},
+		{"commits", webhook.Commits},
+		{"feature_store_runs", webhook.FeatureStoreRuns},
+		{"monitoring_runs", webhook.MonitoringRuns},
+		{"caching_runs", webhook.CachingRuns},
+		{"materialisation_runs", webhook.MaterialisationRuns},
+		{"event_store_runs", webhook.EventStoreRuns},
+	}
+	events := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if subscription.subscribed != nil {
+			events = append(events, subscription.event)
+		}
+	}
+
+	contentType := ""
+	if webhook.ContentType != nil {
+		contentType = *webhook.ContentType
+	}
+
+	return map[string]interface{}{
+		"id":           strconv.Itoa(webhook.ID),
+		"name":         webhook.Name,
+		"description":  webhook.Description,
+		"url":          webhook.URL,
+		"content_type": contentType,
+		"events":       events,
+	}
+}
@@ -0,0 +1,53 @@
+package anaml
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceWebhooks() *schema.Resource {
+	return &schema.Resource{
+		Description: "All Webhooks",
+
+		ReadContext: withDiagnostics(dataSourceWebhooksRead),
+
+		Schema: map[string]*schema.Schema{
+			"webhooks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Webhooks",
+				Elem: &schema.Resource{
+					Schema: webhookDataSchema(map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWebhooksRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+
+	webhooks, err := c.ListWebhooks()
+	if err != nil {
+		return err
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		flattened = append(flattened, flattenWebhook(webhook))
+	}
+
+	d.SetId("all")
+	if err := d.Set("webhooks", flattened); err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,7 +1,6 @@
 package anaml
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -26,8 +25,8 @@ func (c *Client) FindSource(sourceName string) (*Source, error) {
 	}
 
 	source := Source{}
-	err = json.Unmarshal(body, &source)
-	if err != nil {
+	found, err := unmarshalNamed(body, "source", sourceName, &source)
+	if err != nil || !found {
 		return nil, err
 	}
 
@@ -54,8 +53,8 @@ func (c *Client) FindDestination(sourceName string) (*Destination, error) {
 	}
 
 	destination := Destination{}
-	err = json.Unmarshal(body, &destination)
-	if err != nil {
+	found, err := unmarshalNamed(body, "destination", sourceName, &destination)
+	if err != nil || !found {
 		return nil, err
 	}
 
@@ -82,8 +81,8 @@ func (c *Client) FindCluster(sourceName string) (*Cluster, error) {
 	}
 
 	cluster := Cluster{}
-	err = json.Unmarshal(body, &cluster)
-	if err != nil {
+	found, err := unmarshalNamed(body, "cluster", sourceName, &cluster)
+	if err != nil || !found {
 		return nil, err
 	}
 
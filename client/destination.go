@@ -30,49 +30,48 @@ func (c *Client) GetDestination(destinationID string) (*Destination, error) {
 	return &destination, nil
 }
 
-func (c *Client) CreateDestination(creationRequest Destination) (*Destination, error) {
+func (c *Client) CreateDestination(creationRequest Destination) (*Destination, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/destination", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateDestination(destinationID string, creationRequest Destination) error {
+func (c *Client) UpdateDestination(destinationID string, creationRequest Destination) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/destination/%s", c.HostURL, destinationID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteDestination(destinationID string) error {
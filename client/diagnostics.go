@@ -0,0 +1,69 @@
+package anaml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Adapts a create, read, update or delete function to the context aware
+// form, so that its error is reported through errorDiagnostics.
+func withDiagnostics(f func(*schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return errorDiagnostics(f(d, m))
+	}
+}
+
+// Adapts a function which also returns warnings, such as those the
+// server gave for a create or update, to the context aware form. Each
+// warning is reported as a warning diagnostic, alongside any error.
+func withWarnings(f func(*schema.ResourceData, interface{}) ([]string, error)) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		warnings, err := f(d, m)
+		return append(warningDiagnostics(warnings), errorDiagnostics(err)...)
+	}
+}
+
+func warningDiagnostics(warnings []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Anaml server warning",
+			Detail:   warning,
+		})
+	}
+	return diags
+}
+
+// Turns an error into diagnostics. An APIError is summarised by its
+// status and the server's error code, such as "name-conflict", so that
+// automation can tell failures apart, with the response body as detail.
+func errorDiagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return diag.FromErr(err)
+	}
+
+	summary := fmt.Sprintf("Anaml server returned %d %s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+	if apiErr.ErrorCode != "" {
+		summary = fmt.Sprintf("%s, error code %s", summary, apiErr.ErrorCode)
+	}
+	detail := apiErr.Message
+	if err != error(apiErr) {
+		detail = err.Error()
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   detail,
+	}}
+}
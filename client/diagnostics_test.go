@@ -0,0 +1,198 @@
+package anaml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestErrorDiagnostics(t *testing.T) {
+	conflict := &APIError{StatusCode: 409, ErrorCode: "name-conflict", Message: `{"code":"name-conflict"}`}
+	cases := []struct {
+		name        string
+		err         error
+		wantSummary string
+		wantDetail  string
+	}{
+		{"none", nil, "", ""},
+		{"plain", errors.New("boom"), "boom", ""},
+		{"api error with code", conflict, "Anaml server returned 409 Conflict, error code name-conflict", `{"code":"name-conflict"}`},
+		{"api error without code", &APIError{StatusCode: 500, Message: "oops"}, "Anaml server returned 500 Internal Server Error", "oops"},
+		{"wrapped api error", fmt.Errorf("creating entity: %w", conflict), "Anaml server returned 409 Conflict, error code name-conflict", "creating entity: status: 409"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := errorDiagnostics(tt.err)
+			if tt.err == nil {
+				if diags != nil {
+					t.Fatalf("diags = %v, want none", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Severity != diag.Error {
+				t.Fatalf("diags = %v, want one error", diags)
+			}
+			if diags[0].Summary != tt.wantSummary {
+				t.Errorf("summary = %q, want %q", diags[0].Summary, tt.wantSummary)
+			}
+			if !strings.HasPrefix(diags[0].Detail, tt.wantDetail) {
+				t.Errorf("detail = %q, want prefix %q", diags[0].Detail, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestCreateConflict(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"code": "name-conflict", "message": "An entity named customer already exists"}`))
+	})
+
+	_, _, err := c.CreateEntity(Entity{Name: "customer"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusConflict || apiErr.ErrorCode != "name-conflict" {
+		t.Errorf("err = %+v, want a 409 name-conflict", apiErr)
+	}
+
+	d := schema.TestResourceDataRaw(t, ResourceEntity().Schema, map[string]interface{}{
+		"name":           "customer",
+		"default_column": "customer",
+	})
+	diags := ResourceEntity().CreateContext(context.Background(), d, c)
+	if len(diags) != 1 || diags[0].Severity != diag.Error {
+		t.Fatalf("diags = %v, want one error", diags)
+	}
+	if !strings.Contains(diags[0].Summary, "409") || !strings.Contains(diags[0].Summary, "name-conflict") {
+		t.Errorf("summary = %q, want the status and error code", diags[0].Summary)
+	}
+	if !strings.Contains(diags[0].Detail, "already exists") {
+		t.Errorf("detail = %q, want the server's message", diags[0].Detail)
+	}
+}
+
+func TestUpdateWarnings(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			w.Write([]byte(`{"warnings": ["requiredType is deprecated", "defaultColumn is not indexed"]}`))
+		case http.MethodGet:
+			w.Write([]byte(`{"id": 3, "name": "customer", "description": "", "adt_type": "base", "defaultColumn": "customer", "labels": [], "attributes": [], "warnings": ["ignored on reads"]}`))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	warnings, err := c.UpdateEntity("3", Entity{Name: "customer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 2 || warnings[0] != "requiredType is deprecated" {
+		t.Errorf("warnings = %v", warnings)
+	}
+
+	d := schema.TestResourceDataRaw(t, ResourceEntity().Schema, map[string]interface{}{
+		"name":           "customer",
+		"default_column": "customer",
+	})
+	d.SetId("3")
+	diags := ResourceEntity().UpdateContext(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("diags = %v, want two warnings", diags)
+	}
+	for i, want := range []string{"requiredType is deprecated", "defaultColumn is not indexed"} {
+		if diags[i].Severity != diag.Warning || diags[i].Detail != want {
+			t.Errorf("diags[%d] = %+v, want a warning %q", i, diags[i], want)
+		}
+	}
+}
+
+func TestCreateWithoutWarnings(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`3`))
+	})
+
+	entity, warnings, err := c.CreateEntity(Entity{Name: "customer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entity.ID != 3 || warnings != nil {
+		t.Errorf("entity = %+v, warnings = %v", entity, warnings)
+	}
+}
+
+func TestCreateWarnings(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Write([]byte(`{"id": 3, "warnings": ["requiredType is deprecated"]}`))
+		case http.MethodGet:
+			w.Write([]byte(`{"id": 3, "name": "customer", "description": "", "adt_type": "base", "defaultColumn": "customer", "labels": [], "attributes": []}`))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	entity, warnings, err := c.CreateEntity(Entity{Name: "customer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entity.ID != 3 || len(warnings) != 1 || warnings[0] != "requiredType is deprecated" {
+		t.Errorf("entity = %+v, warnings = %v", entity, warnings)
+	}
+
+	d := schema.TestResourceDataRaw(t, ResourceEntity().Schema, map[string]interface{}{
+		"name":           "customer",
+		"default_column": "customer",
+	})
+	diags := ResourceEntity().CreateContext(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if d.Id() != "3" {
+		t.Errorf("ID = %q, want 3", d.Id())
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || diags[0].Detail != "requiredType is deprecated" {
+		t.Errorf("diags = %v, want one warning", diags)
+	}
+}
+
+func TestUnmarshalCreatedID(t *testing.T) {
+	cases := []struct {
+		body    string
+		want    int
+		wantErr bool
+	}{
+		{`3`, 3, false},
+		{" 3\n", 3, false},
+		{`{"id": 3}`, 3, false},
+		{`{"id": 3, "warnings": ["deprecated"]}`, 3, false},
+		{`{"warnings": ["deprecated"]}`, 0, true},
+		{`"3"`, 0, true},
+		{``, 0, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.body, func(t *testing.T) {
+			got, err := unmarshalCreatedID([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("unmarshalCreatedID(%s) = %d, want %d", tt.body, got, tt.want)
+			}
+		})
+	}
+}
@@ -30,49 +30,48 @@ func (c *Client) GetEntity(entityID string) (*Entity, error) {
 	return &entity, nil
 }
 
-func (c *Client) CreateEntity(creationRequest Entity) (*Entity, error) {
+func (c *Client) CreateEntity(creationRequest Entity) (*Entity, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/entity", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateEntity(entityID string, creationRequest Entity) error {
+func (c *Client) UpdateEntity(entityID string, creationRequest Entity) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/entity/%s", c.HostURL, entityID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteEntity(entityID string) error {
@@ -109,10 +108,53 @@ func (c *Client) FindEntityByName(name string) (*Entity, error) {
 	}
 
 	item := Entity{}
-	err = json.Unmarshal(body, &item)
-	if err != nil {
+	found, err := unmarshalNamed(body, "entity", name, &item)
+	if err != nil || !found {
 		return nil, err
 	}
 
 	return &item, nil
 }
+
+// Lists the entities which carry every one of the given labels.
+func (c *Client) ListEntitiesByLabels(labels []string) ([]Entity, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/entity", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server matches labels exactly, so it can't filter for us when
+	// labels are case insensitive.
+	if !c.CaseInsensitiveLabels {
+		q := req.URL.Query()
+		for _, label := range labels {
+			q.Add("label", label)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []Entity{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only keep the matching entities, in case the server ignores the filter.
+	res := make([]Entity, 0, len(items))
+	for _, item := range items {
+		if c.hasLabels(item.Labels, labels) {
+			res = append(res, item)
+		}
+	}
+
+	return res, nil
+}
@@ -30,49 +30,48 @@ func (c *Client) GetEntityMapping(entityID string) (*EntityMapping, error) {
 	return &entity, nil
 }
 
-func (c *Client) CreateEntityMapping(creationRequest EntityMapping) (*EntityMapping, error) {
+func (c *Client) CreateEntityMapping(creationRequest EntityMapping) (*EntityMapping, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/entity-mapping", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateEntityMapping(entityID string, creationRequest EntityMapping) error {
+func (c *Client) UpdateEntityMapping(entityID string, creationRequest EntityMapping) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/entity-mapping/%s", c.HostURL, entityID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteEntityMapping(entityID string) error {
@@ -50,57 +50,56 @@ func (c *Client) FindEntityPopulationByName(sourceName string) (*EntityPopulatio
 	}
 
 	population := EntityPopulation{}
-	err = json.Unmarshal(body, &population)
-	if err != nil {
+	found, err := unmarshalNamed(body, "entity population", sourceName, &population)
+	if err != nil || !found {
 		return nil, err
 	}
 
 	return &population, nil
 }
 
-func (c *Client) CreateEntityPopulation(creationRequest EntityPopulation) (*EntityPopulation, error) {
+func (c *Client) CreateEntityPopulation(creationRequest EntityPopulation) (*EntityPopulation, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/entity-population", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateEntityPopulation(entityID string, creationRequest EntityPopulation) error {
+func (c *Client) UpdateEntityPopulation(entityID string, creationRequest EntityPopulation) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/entity-population/%s", c.HostURL, entityID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteEntityPopulation(entityID string) error {
@@ -30,49 +30,48 @@ func (c *Client) GetEventStore(EventStoreId string) (*EventStore, error) {
 	return &entity, nil
 }
 
-func (c *Client) CreateEventStore(creationRequest EventStore) (*EventStore, error) {
+func (c *Client) CreateEventStore(creationRequest EventStore) (*EventStore, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/event-store", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateEventStore(EventStoreId string, creationRequest EventStore) error {
+func (c *Client) UpdateEventStore(EventStoreId string, creationRequest EventStore) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/event-store/%s", c.HostURL, EventStoreId), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteEventStore(EventStoreId string) error {
@@ -109,8 +108,8 @@ func (c *Client) FindEventStoreByName(name string) (*EventStore, error) {
 	}
 
 	item := EventStore{}
-	err = json.Unmarshal(body, &item)
-	if err != nil {
+	found, err := unmarshalNamed(body, "event store", name, &item)
+	if err != nil || !found {
 		return nil, err
 	}
 
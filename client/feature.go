@@ -52,8 +52,8 @@ func (c *Client) FindFeatureByName(featureName string) (*Feature, error) {
 	}
 
 	item := Feature{}
-	err = json.Unmarshal(body, &item)
-	if err != nil {
+	found, err := unmarshalNamed(body, "feature", featureName, &item)
+	if err != nil || !found {
 		return nil, err
 	}
 
@@ -95,49 +95,48 @@ func (c *Client) FindFeatureByTemplate(templateId int, rows int, days int) (*Fea
 	return &feature, nil
 }
 
-func (c *Client) CreateFeature(creationRequest Feature) (*Feature, error) {
+func (c *Client) CreateFeature(creationRequest Feature) (*Feature, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateFeature(featureID string, creationRequest Feature) error {
+func (c *Client) UpdateFeature(featureID string, creationRequest Feature) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/feature/%s", c.HostURL, featureID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteFeature(featureID string) error {
@@ -153,3 +152,81 @@ func (c *Client) DeleteFeature(featureID string) error {
 
 	return nil
 }
+
+// GetFeatureLineage returns the objects which depend on a feature.
+func (c *Client) GetFeatureLineage(featureID string) (*FeatureLineage, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature/%s/lineage", c.HostURL, featureID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	lineage := FeatureLineage{}
+	err = json.Unmarshal(body, &lineage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lineage, nil
+}
+
+// CreateFeaturesBatch creates several features in a single request. It
+// returns nil if the server doesn't provide the batch endpoint.
+func (c *Client) CreateFeaturesBatch(creationRequests []Feature) ([]Feature, error) {
+	rb, err := json.Marshal(creationRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature/batch", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	var V []int
+	err = json.Unmarshal(body, &V)
+	if err != nil {
+		return nil, err
+	}
+	if len(V) != len(creationRequests) {
+		return nil, fmt.Errorf("Batch created %d features, expected %d", len(V), len(creationRequests))
+	}
+
+	features := make([]Feature, len(creationRequests))
+	for i, creationRequest := range creationRequests {
+		creationRequest.ID = V[i]
+		features[i] = creationRequest
+	}
+	return features, nil
+}
+
+// CreateFeatureBatched creates a feature, grouping it with any other
+// features created at about the same time into a single batch request.
+// Terraform creates resources concurrently, so when many features are
+// applied at once this issues far fewer requests than CreateFeature.
+// If the server doesn't support batching, rejects the batch, or batching
+// is disabled, each feature is created on its own.
+func (c *Client) CreateFeatureBatched(creationRequest Feature) (*Feature, []string, error) {
+	if c.DisableFeatureBatching {
+		return c.CreateFeature(creationRequest)
+	}
+	res := <-c.featureBatcher.enqueue(c, creationRequest)
+	return res.feature, res.warnings, res.err
+}
@@ -0,0 +1,87 @@
+package anaml
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// How long to wait for other features to join a batch before sending it.
+const featureBatchWindow = 100 * time.Millisecond
+
+type featureBatchResult struct {
+	feature  *Feature
+	warnings []string
+	err      error
+}
+
+type pendingFeature struct {
+	request Feature
+	result  chan featureBatchResult
+}
+
+// Collects feature creation requests made within featureBatchWindow of
+// each other so they can be sent as one batch.
+type featureBatcher struct {
+	mu          sync.Mutex
+	pending     []pendingFeature
+	unsupported bool
+}
+
+func (b *featureBatcher) enqueue(c *Client, request Feature) chan featureBatchResult {
+	result := make(chan featureBatchResult, 1)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = append(b.pending, pendingFeature{request: request, result: result})
+	if len(b.pending) == 1 {
+		time.AfterFunc(featureBatchWindow, func() { b.flush(c) })
+	}
+	return result
+}
+
+func (b *featureBatcher) flush(c *Client) {
+	b.mu.Lock()
+	pending := b.pending
+	unsupported := b.unsupported
+	b.pending = nil
+	b.mu.Unlock()
+
+	if len(pending) > 1 && !unsupported {
+		requests := make([]Feature, len(pending))
+		for i, p := range pending {
+			requests[i] = p.request
+		}
+
+		// The server rejects the whole batch if any feature in it is
+		// invalid, so on an API error each feature is created on its own
+		// to find which failed and why.
+		features, err := c.CreateFeaturesBatch(requests)
+		var apiErr *APIError
+		switch {
+		case errors.As(err, &apiErr):
+			log.Printf("[DEBUG] Feature batch failed, creating each feature on its own: %v", err)
+		case err != nil:
+			for _, p := range pending {
+				p.result <- featureBatchResult{err: err}
+			}
+			return
+		case features != nil:
+			for i, p := range pending {
+				p.result <- featureBatchResult{feature: &features[i]}
+			}
+			return
+		default:
+			b.mu.Lock()
+			b.unsupported = true
+			b.mu.Unlock()
+		}
+	}
+
+	for _, p := range pending {
+		feature, warnings, err := c.CreateFeature(p.request)
+		p.result <- featureBatchResult{feature: feature, warnings: warnings, err: err}
+	}
+}
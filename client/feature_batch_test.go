@@ -0,0 +1,118 @@
+package anaml
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Serves feature creation, counting the requests made. The batch
+// endpoint answers with batchStatus when it is set.
+type featureServer struct {
+	batchStatus int
+	requests    int32
+	batches     int32
+	nextID      int32
+}
+
+func (s *featureServer) handle(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&s.requests, 1)
+	switch r.URL.Path {
+	case "/feature/batch":
+		atomic.AddInt32(&s.batches, 1)
+		if s.batchStatus != 0 {
+			w.WriteHeader(s.batchStatus)
+			return
+		}
+		var features []Feature
+		if err := json.NewDecoder(r.Body).Decode(&features); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ids := make([]int32, len(features))
+		for i := range features {
+			ids[i] = atomic.AddInt32(&s.nextID, 1)
+		}
+		json.NewEncoder(w).Encode(ids)
+	case "/feature":
+		json.NewEncoder(w).Encode(atomic.AddInt32(&s.nextID, 1))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// Creates count features at once, as Terraform does when applying many
+// feature resources, and checks each was given an ID.
+func createFeaturesConcurrently(t *testing.T, c *Client, count int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			feature, _, err := c.CreateFeatureBatched(Feature{Name: "feature"})
+			if err == nil && feature.ID == 0 {
+				t.Error("feature was created without an ID")
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCreateFeatureBatched(t *testing.T) {
+	const features = 50
+
+	cases := []struct {
+		name         string
+		batchStatus  int
+		disabled     bool
+		wantRequests int32
+	}{
+		// All of the features go in the one batch request, rather than
+		// one request each.
+		{name: "batched", wantRequests: 1},
+		// A server without the batch endpoint costs one extra request.
+		{name: "no batch endpoint", batchStatus: http.StatusNotFound, wantRequests: features + 1},
+		// A rejected batch is retried feature by feature.
+		{name: "batch rejected", batchStatus: http.StatusBadRequest, wantRequests: features + 1},
+		{name: "disabled", disabled: true, wantRequests: features},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &featureServer{batchStatus: tt.batchStatus}
+			c := newTestClient(t, server.handle)
+			c.DisableFeatureBatching = tt.disabled
+
+			createFeaturesConcurrently(t, c, features)
+
+			requests := atomic.LoadInt32(&server.requests)
+			t.Logf("%d features created with %d requests", features, requests)
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestCreateFeatureBatchedRemembersMissingEndpoint(t *testing.T) {
+	server := &featureServer{batchStatus: http.StatusNotFound}
+	c := newTestClient(t, server.handle)
+
+	createFeaturesConcurrently(t, c, 5)
+	createFeaturesConcurrently(t, c, 5)
+
+	if batches := atomic.LoadInt32(&server.batches); batches != 1 {
+		t.Errorf("batch endpoint was tried %d times, want once", batches)
+	}
+}
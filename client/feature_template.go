@@ -31,49 +31,48 @@ func (c *Client) GetFeatureTemplate(featureID string) (*FeatureTemplate, error)
 	return &feature, nil
 }
 
-func (c *Client) CreateFeatureTemplate(creationRequest FeatureTemplate) (*FeatureTemplate, error) {
+func (c *Client) CreateFeatureTemplate(creationRequest FeatureTemplate) (*FeatureTemplate, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature-template", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateFeatureTemplate(templateID string, creationRequest FeatureTemplate) error {
+func (c *Client) UpdateFeatureTemplate(templateID string, creationRequest FeatureTemplate) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/feature-template/%s", c.HostURL, templateID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteFeatureTemplate(templateID string) error {
@@ -110,8 +109,8 @@ func (c *Client) FindFeatureTemplateByName(name string) (*FeatureTemplate, error
 	}
 
 	item := FeatureTemplate{}
-	err = json.Unmarshal(body, &item)
-	if err != nil {
+	found, err := unmarshalNamed(body, "feature template", name, &item)
+	if err != nil || !found {
 		return nil, err
 	}
 
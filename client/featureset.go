@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -30,49 +31,48 @@ func (c *Client) GetFeatureSet(FeatureSetID string) (*FeatureSet, error) {
 	return &FeatureSet, nil
 }
 
-func (c *Client) CreateFeatureSet(creationRequest FeatureSet) (*FeatureSet, error) {
+func (c *Client) CreateFeatureSet(creationRequest FeatureSet) (*FeatureSet, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature-set", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateFeatureSet(FeatureSetID string, creationRequest FeatureSet) error {
+func (c *Client) UpdateFeatureSet(FeatureSetID string, creationRequest FeatureSet) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/feature-set/%s", c.HostURL, FeatureSetID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteFeatureSet(FeatureSetID string) error {
@@ -109,10 +109,78 @@ func (c *Client) FindFeatureSetByName(name string) (*FeatureSet, error) {
 	}
 
 	item := FeatureSet{}
-	err = json.Unmarshal(body, &item)
-	if err != nil {
+	found, err := unmarshalNamed(body, "feature set", name, &item)
+	if err != nil || !found {
 		return nil, err
 	}
 
 	return &item, nil
 }
+
+// ListFeatureSetsByEntity returns the feature sets for an entity.
+func (c *Client) ListFeatureSetsByEntity(entityID int) ([]FeatureSet, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature-set", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("entity", strconv.Itoa(entityID))
+	req.URL.RawQuery = q.Encode()
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []FeatureSet{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only keep the entity's feature sets, in case the server ignores the filter.
+	res := make([]FeatureSet, 0, len(items))
+	for _, item := range items {
+		if item.EntityID == entityID {
+			res = append(res, item)
+		}
+	}
+
+	return res, nil
+}
+
+// Computes the feature set's values for a small sample of entities
+// without running a feature store. Each row maps column names to values.
+func (c *Client) PreviewFeatures(featureSetID int, entities []string) ([]map[string]interface{}, error) {
+	rb, err := json.Marshal(FeaturePreviewRequest{Entities: entities})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature-set/%s/preview", c.HostURL, strconv.Itoa(featureSetID)), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	rows := []map[string]interface{}{}
+	err = json.Unmarshal(body, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -30,49 +31,48 @@ func (c *Client) GetFeatureStore(FeatureStoreID string) (*FeatureStore, error) {
 	return &FeatureStore, nil
 }
 
-func (c *Client) CreateFeatureStore(creationRequest FeatureStore) (*FeatureStore, error) {
+func (c *Client) CreateFeatureStore(creationRequest FeatureStore) (*FeatureStore, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature-store", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateFeatureStore(FeatureStoreID string, creationRequest FeatureStore) error {
+func (c *Client) UpdateFeatureStore(FeatureStoreID string, creationRequest FeatureStore) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/feature-store/%s", c.HostURL, FeatureStoreID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteFeatureStore(FeatureStoreID string) error {
@@ -89,6 +89,52 @@ func (c *Client) DeleteFeatureStore(FeatureStoreID string) error {
 	return nil
 }
 
+func (c *Client) RunFeatureStore(FeatureStoreID string) (*FeatureStoreRun, []string, error) {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature-store/%s/run", c.HostURL, FeatureStoreID), strings.NewReader("{}"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, warnings, err := c.doWriteRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if body == nil {
+		return nil, nil, fmt.Errorf("Feature store %s does not exist", FeatureStoreID)
+	}
+
+	V, err := unmarshalCreatedID(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	featureStoreID, _ := strconv.Atoi(FeatureStoreID)
+	return &FeatureStoreRun{ID: V, FeatureStoreID: featureStoreID}, warnings, nil
+}
+
+func (c *Client) GetFeatureStoreRun(FeatureStoreID string, RunID string) (*FeatureStoreRun, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature-store/%s/run/%s", c.HostURL, FeatureStoreID, RunID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, nil
+	}
+
+	FeatureStoreRun := FeatureStoreRun{}
+	err = json.Unmarshal(body, &FeatureStoreRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FeatureStoreRun, nil
+}
+
 func (c *Client) FindFeatureStoreByName(name string) (*FeatureStore, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature-store", c.HostURL), nil)
 	if err != nil {
@@ -109,8 +155,8 @@ func (c *Client) FindFeatureStoreByName(name string) (*FeatureStore, error) {
 	}
 
 	item := FeatureStore{}
-	err = json.Unmarshal(body, &item)
-	if err != nil {
+	found, err := unmarshalNamed(body, "feature store", name, &item)
+	if err != nil || !found {
 		return nil, err
 	}
 
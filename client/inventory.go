@@ -0,0 +1,39 @@
+package anaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// InventoryObject identifies an object of any type.
+type InventoryObject struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Lists the ID and name of every object at the given endpoint, such as
+// "feature-set". Returns nil if the endpoint doesn't exist.
+func (c *Client) ListObjects(endpoint string) ([]InventoryObject, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", c.HostURL, endpoint), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []InventoryObject{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
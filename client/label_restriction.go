@@ -30,49 +30,71 @@ func (c *Client) GetLabelRestriction(labelID string) (*LabelRestriction, error)
 	return &label, nil
 }
 
-func (c *Client) CreateLabelRestriction(creationRequest LabelRestriction) (*LabelRestriction, error) {
-	rb, err := json.Marshal(creationRequest)
+func (c *Client) ListLabelRestrictions() ([]LabelRestriction, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/allowed-label", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/allowed-label", c.HostURL), strings.NewReader(string(rb)))
+	body, err := c.doRequest(req)
 	if err != nil {
 		return nil, err
 	}
+	if body == nil {
+		return nil, nil
+	}
 
-	body, err := c.doRequest(req)
+	labels := []LabelRestriction{}
+	err = json.Unmarshal(body, &labels)
 	if err != nil {
 		return nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	return labels, nil
+}
+
+func (c *Client) CreateLabelRestriction(creationRequest LabelRestriction) (*LabelRestriction, []string, error) {
+	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/allowed-label", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, warnings, err := c.doWriteRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	V, err := unmarshalCreatedID(body)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateLabelRestriction(labelID string, creationRequest LabelRestriction) error {
+func (c *Client) UpdateLabelRestriction(labelID string, creationRequest LabelRestriction) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/allowed-label/%s", c.HostURL, labelID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteLabelRestriction(labelID string) error {
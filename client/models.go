@@ -11,6 +11,7 @@ type Entity struct {
 	Entities      *[]int       `json:"entities,omitempty"`
 	Labels        []string     `json:"labels"`
 	Attributes    []Attribute  `json:"attributes"`
+	AuditMetadata
 }
 
 // EntityMapping ..
@@ -23,6 +24,9 @@ type EntityMapping struct {
 }
 
 // EntityPopulation ..
+//
+// The server expects Sources as [] rather than null when it's empty, so
+// it's built with expandIdentifierList rather than left as a nil slice.
 type EntityPopulation struct {
 	ID          int         `json:"id,omitempty"`
 	Name        string      `json:"name"`
@@ -46,6 +50,14 @@ type EventDescription struct {
 	TimestampInfo *TimestampInfo    `json:"timestampInfo"`
 }
 
+// AuditMetadata is maintained by the server, and never sent by the
+// provider.
+type AuditMetadata struct {
+	CreatedAt string       `json:"createdAt,omitempty"`
+	UpdatedAt string       `json:"updatedAt,omitempty"`
+	Version   AuditVersion `json:"version,omitempty"`
+}
+
 // Table ...
 type Table struct {
 	ID            int               `json:"id,omitempty"`
@@ -60,6 +72,7 @@ type Table struct {
 	ExtraFeatures []int             `json:"extraFeatures,omitempty"`
 	Labels        []string          `json:"labels"`
 	Attributes    []Attribute       `json:"attributes"`
+	AuditMetadata
 }
 
 // EventWindow ...
@@ -103,6 +116,14 @@ type Feature struct {
 	TemplateID  *int                 `json:"template,omitempty"`
 	Labels      []string             `json:"labels"`
 	Attributes  []Attribute          `json:"attributes"`
+	AuditMetadata
+}
+
+// FeatureLineage lists the feature sets which include a feature, and the
+// tables downstream of it.
+type FeatureLineage struct {
+	FeatureSets []int `json:"featureSets"`
+	Tables      []int `json:"tables"`
 }
 
 // FeatureTemplate ... again, completely normalised.
@@ -122,6 +143,7 @@ type FeatureTemplate struct {
 	EntityID    int                  `json:"entityId,omitempty"`
 	Labels      []string             `json:"labels"`
 	Attributes  []Attribute          `json:"attributes"`
+	AuditMetadata
 }
 
 // FeatureSet ...
@@ -133,6 +155,12 @@ type FeatureSet struct {
 	Features    []int       `json:"features"`
 	Labels      []string    `json:"labels"`
 	Attributes  []Attribute `json:"attributes"`
+	AuditMetadata
+}
+
+// FeaturePreviewRequest ...
+type FeaturePreviewRequest struct {
+	Entities []string `json:"entities"`
 }
 
 // VersionTarget ...
@@ -142,6 +170,14 @@ type VersionTarget struct {
 	Branch *string `json:"branchName,omitempty"`
 }
 
+// FeatureStoreRun ...
+type FeatureStoreRun struct {
+	ID             int     `json:"id"`
+	FeatureStoreID int     `json:"featureStoreId"`
+	Status         string  `json:"status"`
+	Error          *string `json:"error,omitempty"`
+}
+
 // FeatureStore ...
 type FeatureStore struct {
 	ID                        int                    `json:"id,omitempty"`
@@ -159,12 +195,15 @@ type FeatureStore struct {
 	AdditionalSparkProperties map[string]string      `json:"additionalSparkProperties,omitempty"`
 	RunDateOffset             *int                   `json:"runDateOffset,omitempty"`
 	Principal                 *int                   `json:"principal,omitempty"`
+	Owner                     *int                   `json:"owner,omitempty"`
 	Population                *int                   `json:"entityPopulation,omitempty"`
 	StartDate                 *string                `json:"startDate,omitempty"`
 	EndDate                   *string                `json:"endDate,omitempty"`
 	Table                     *int                   `json:"table,omitempty"`
 	IncludeMetadata           bool                   `json:"includeMetadata"`
+	MetadataColumns           []string               `json:"metadataColumns,omitempty"`
 	VersionTarget             *VersionTarget         `json:"versionTarget,omitempty"`
+	AuditMetadata
 }
 
 type Schedule struct {
@@ -225,35 +264,49 @@ type Source struct {
 	Type                string                          `json:"adt_type"`
 	Bucket              string                          `json:"bucket,omitempty"`
 	Path                string                          `json:"path,omitempty"`
+	Query               string                          `json:"query,omitempty"`
 	FileFormat          *FileFormat                     `json:"fileFormat,omitempty"`
+	RecursiveFileLookup *bool                           `json:"recursiveFileLookup,omitempty"`
+	PathGlobFilter      *string                         `json:"pathGlobFilter,omitempty"`
+	TimestampTimezone   *string                         `json:"timestampTimezone,omitempty"`
 	Endpoint            string                          `json:"endpoint,omitempty"`
+	Region              string                          `json:"region,omitempty"`
 	AccessKey           string                          `json:"accessKey,omitempty"`
 	SecretKey           string                          `json:"secretKey,omitempty"`
 	URL                 string                          `json:"url,omitempty"`
 	Schema              string                          `json:"schema,omitempty"`
 	CredentialsProvider *LoginCredentialsProviderConfig `json:"credentialsProvider,omitempty"`
 	Database            string                          `json:"database,omitempty"`
+	TableProperties     map[string]string               `json:"tableProperties,omitempty"`
+	PartitionColumns    []string                        `json:"partitionColumns,omitempty"`
 	BootstrapServers    string                          `json:"bootstrapServers,omitempty"`
 	SchemaRegistryURL   string                          `json:"schemaRegistryUrl,omitempty"`
 	KafkaProperties     []SensitiveAttribute            `json:"kafkaPropertiesProviders"`
+	Streaming           bool                            `json:"streaming,omitempty"`
 	Labels              []string                        `json:"labels"`
 	Attributes          []Attribute                     `json:"attributes"`
 	Warehouse           string                          `json:"warehouse,omitempty"`
 	AccessRules         []AccessRule                    `json:"accessRules"`
+	AuditMetadata
 }
 
 type FileFormat struct {
-	Type                     string  `json:"adt_type"`
-	Sep                      *string `json:"sep,omitempty"`
-	QuoteAll                 *bool   `json:"quoteAll,omitempty"`
-	IncludeHeader            *bool   `json:"includeHeader,omitempty"`
-	EmptyValue               *string `json:"emptyValue,omitempty"`
-	Compression              *string `json:"compression,omitempty"`
-	DateFormat               *string `json:"dateFormat,omitempty"`
-	TimestampFormat          *string `json:"timestampFormat,omitempty"`
-	IgnoreLeadingWhiteSpace  *bool   `json:"ignoreLeadingWhiteSpace,omitempty"`
-	IgnoreTrailingWhiteSpace *bool   `json:"ignoreTrailingWhiteSpace,omitempty"`
-	LineSep                  *string `json:"lineSep,omitempty"`
+	Type                     string   `json:"adt_type"`
+	Sep                      *string  `json:"sep,omitempty"`
+	QuoteAll                 *bool    `json:"quoteAll,omitempty"`
+	IncludeHeader            *bool    `json:"includeHeader,omitempty"`
+	EmptyValue               *string  `json:"emptyValue,omitempty"`
+	Compression              *string  `json:"compression,omitempty"`
+	DateFormat               *string  `json:"dateFormat,omitempty"`
+	TimestampFormat          *string  `json:"timestampFormat,omitempty"`
+	IgnoreLeadingWhiteSpace  *bool    `json:"ignoreLeadingWhiteSpace,omitempty"`
+	IgnoreTrailingWhiteSpace *bool    `json:"ignoreTrailingWhiteSpace,omitempty"`
+	LineSep                  *string  `json:"lineSep,omitempty"`
+	BloomFilterColumns       []string `json:"bloomFilterColumns,omitempty"`
+	StripeSize               *int     `json:"stripeSize,omitempty"`
+	RowGroupSize             *int     `json:"rowGroupSize,omitempty"`
+	PageSize                 *int     `json:"pageSize,omitempty"`
+	EnableDictionary         *bool    `json:"enableDictionary,omitempty"`
 }
 
 type KafkaFormat struct {
@@ -283,9 +336,12 @@ type AccessRule struct {
 
 // MaskingRule ...
 type MaskingRule struct {
-	Type       string `json:"adt_type"`
-	Expression string `json:"expression"`
-	Column     string `json:"column,omitempty"`
+	Type        string  `json:"adt_type"`
+	Expression  string  `json:"expression"`
+	Column      string  `json:"column,omitempty"`
+	Pattern     string  `json:"pattern,omitempty"`
+	Replacement *string `json:"replacement,omitempty"`
+	Condition   *string `json:"condition,omitempty"`
 }
 
 // Destination ...
@@ -295,6 +351,7 @@ type Destination struct {
 	Description         string                          `json:"description"`
 	Labels              []string                        `json:"labels"`
 	Attributes          []Attribute                     `json:"attributes"`
+	AccessRules         []AccessRule                    `json:"accessRules"`
 	Type                string                          `json:"adt_type"`
 	Bucket              string                          `json:"bucket,omitempty"`
 	Path                string                          `json:"path,omitempty"`
@@ -306,6 +363,8 @@ type Destination struct {
 	Schema              string                          `json:"schema,omitempty"`
 	CredentialsProvider *LoginCredentialsProviderConfig `json:"credentialsProvider,omitempty"`
 	Database            string                          `json:"database,omitempty"`
+	TableProperties     map[string]string               `json:"tableProperties,omitempty"`
+	PartitionColumns    []string                        `json:"partitionColumns,omitempty"`
 	BootstrapServers    string                          `json:"bootstrapServers,omitempty"`
 	SchemaRegistryURL   string                          `json:"schemaRegistryUrl,omitempty"`
 	KafkaProperties     []SensitiveAttribute            `json:"kafkaPropertiesProviders"`
@@ -313,6 +372,20 @@ type Destination struct {
 	Warehouse           string                          `json:"warehouse,omitempty"`
 	Project             string                          `json:"project,omitempty"`
 	Instance            string                          `json:"instance,omitempty"`
+	TableName           string                          `json:"tableName,omitempty"`
+	Region              string                          `json:"region,omitempty"`
+	ContactPoints       string                          `json:"contactPoints,omitempty"`
+	Keyspace            string                          `json:"keyspace,omitempty"`
+	Table               string                          `json:"table,omitempty"`
+	Host                string                          `json:"host,omitempty"`
+	Port                int                             `json:"port,omitempty"`
+	Password            *SecretValueConfig              `json:"password,omitempty"`
+	KeyPrefix           string                          `json:"keyPrefix,omitempty"`
+	WriteMode           string                          `json:"writeMode,omitempty"`
+	ConflictColumns     []string                        `json:"conflictColumns,omitempty"`
+	WriteDisposition    string                          `json:"writeDisposition,omitempty"`
+	CreateDisposition   string                          `json:"createDisposition,omitempty"`
+	AuditMetadata
 }
 
 // GCSStagingArea ...
@@ -344,21 +417,28 @@ type Cluster struct {
 	IsPreviewCluster    bool                            `json:"isPreviewCluster"`
 	AnamlServerURL      string                          `json:"anamlServerUrl,omitempty"`
 	SparkServerURL      string                          `json:"sparkServerUrl,omitempty"`
+	MinWorkers          *int                            `json:"minWorkers,omitempty"`
+	MaxWorkers          *int                            `json:"maxWorkers,omitempty"`
+	NodeType            string                          `json:"nodeType,omitempty"`
 	CredentialsProvider *LoginCredentialsProviderConfig `json:"credentialsProvider,omitempty"`
 	SparkConfig         *SparkConfig                    `json:"sparkConfig,omitempty"`
 	PropertySet         []PropertySet                   `json:"propertySets"`
 	Labels              []string                        `json:"labels"`
 	Attributes          []Attribute                     `json:"attributes"`
+	AuditMetadata
 }
 
 // LoginCredentialsProviderConfig  ...
 type LoginCredentialsProviderConfig struct {
-	Type                  string `json:"adt_type"`
-	Username              string `json:"username"`
-	Password              string `json:"password,omitempty"`
-	FilePath              string `json:"filepath,omitempty"`
-	PasswordSecretProject string `json:"passwordSecretProject,omitempty"`
-	PasswordSecretId      string `json:"passwordSecretId,omitempty"`
+	Type                  string             `json:"adt_type"`
+	Username              string             `json:"username"`
+	Password              string             `json:"password,omitempty"`
+	FilePath              string             `json:"filepath,omitempty"`
+	PasswordSecretProject string             `json:"passwordSecretProject,omitempty"`
+	PasswordSecretId      string             `json:"passwordSecretId,omitempty"`
+	Token                 *SecretValueConfig `json:"token,omitempty"`
+	TokenEndpoint         string             `json:"tokenEndpoint,omitempty"`
+	ClientId              string             `json:"clientId,omitempty"`
 }
 
 // SparkConfig ...
@@ -375,6 +455,14 @@ type PropertySet struct {
 	AdditionalSparkProperties map[string]string `json:"additionalSparkProperties"`
 }
 
+// SparkPropertyBundle ...
+type SparkPropertyBundle struct {
+	ID                        int               `json:"id,omitempty"`
+	Name                      string            `json:"name"`
+	Description               string            `json:"description"`
+	AdditionalSparkProperties map[string]string `json:"additionalSparkProperties"`
+}
+
 // User ...
 type Role struct {
 	Type string `json:"adt_type"`
@@ -397,6 +485,7 @@ type AccessToken struct {
 	Owner       *int   `json:"owner,omitempty"`
 	Description string `json:"description,omitempty"`
 	Roles       []Role `json:"roles"`
+	Projects    []int  `json:"projects,omitempty"`
 }
 
 type ChangeOtherPasswordRequest struct {
@@ -490,18 +579,33 @@ type TableCachingSpec struct {
 }
 
 type Webhook struct {
-	ID                   int       `json:"id,omitempty"`
-	Name                 string    `json:"name"`
-	Description          string    `json:"description"`
-	URL                  string    `json:"url"`
-	MergeRequests        *struct{} `json:"mergeRequests,omitempty"`
-	MergeRequestComments *struct{} `json:"mergeRequestComments,omitempty"`
-	Commits              *struct{} `json:"commits,omitempty"`
-	FeatureStoreRuns     *struct{} `json:"featureStoreRuns,omitempty"`
-	MonitoringRuns       *struct{} `json:"monitoringRuns,omitempty"`
-	CachingRuns          *struct{} `json:"cachingRuns,omitempty"`
-	MaterialisationRuns  *struct{} `json:"materialisationRuns,omitempty"`
-	EventStoreRuns       *struct{} `json:"eventStoreRuns,omitempty"`
+	ID                   int             `json:"id,omitempty"`
+	Name                 string          `json:"name"`
+	Description          string          `json:"description"`
+	URL                  string          `json:"url"`
+	ContentType          *string         `json:"contentType,omitempty"`
+	Headers              []WebhookHeader `json:"headers"`
+	MergeRequests        *struct{}       `json:"mergeRequests,omitempty"`
+	MergeRequestComments *struct{}       `json:"mergeRequestComments,omitempty"`
+	Commits              *struct{}       `json:"commits,omitempty"`
+	FeatureStoreRuns     *struct{}       `json:"featureStoreRuns,omitempty"`
+	MonitoringRuns       *struct{}       `json:"monitoringRuns,omitempty"`
+	CachingRuns          *struct{}       `json:"cachingRuns,omitempty"`
+	MaterialisationRuns  *struct{}       `json:"materialisationRuns,omitempty"`
+	EventStoreRuns       *struct{}       `json:"eventStoreRuns,omitempty"`
+}
+
+// WebhookHeader ...
+type WebhookHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WebhookTestResult ...
+// The response from the webhook endpoint when sent a ping event.
+type WebhookTestResult struct {
+	Status int    `json:"status"`
+	Body   string `json:"body,omitempty"`
 }
 
 type Attribute struct {
@@ -657,7 +761,7 @@ func mapRolesToFrontend(backend []Role) []string {
 			vs = append(vs, "edit_projects")
 		} else if v.Type == "runcaching" {
 			vs = append(vs, "run_caching")
-		} else if v.Type == "runevent_store" {
+		} else if v.Type == "runeventstore" {
 			vs = append(vs, "run_event_store")
 		} else if v.Type == "runfeaturegen" {
 			vs = append(vs, "run_featuregen")
@@ -1,7 +1,9 @@
 package anaml
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -15,25 +17,32 @@ AccessTokens are how to authenticate with Anaml programmatically.
 
 func ResourceAccessToken() *schema.Resource {
 	return &schema.Resource{
-		Description: webhooksDescription,
-		Create:      resourceAccessTokenCreate,
-		Read:        resourceAccessTokenRead,
-		Delete:      resourceAccessTokenDelete,
+		Description:   webhooksDescription,
+		CreateContext: withWarnings(resourceAccessTokenCreate),
+		ReadContext:   withDiagnostics(resourceAccessTokenRead),
+		DeleteContext: withDiagnostics(resourceAccessTokenDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importAccessToken,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"owner": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
 				ForceNew:     true,
+				Description:  "The name or ID of the user who owns the access token.",
+				ValidateFunc: validateOwner(),
+			},
+			"owner_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the user who owns the access token, which is how the token is found again when refreshing",
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"roles": {
 				Type:     schema.TypeList,
@@ -44,6 +53,17 @@ func ResourceAccessToken() *schema.Resource {
 					ValidateFunc: validation.StringInSlice(validRoles(), false),
 				},
 			},
+			"projects": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The IDs of the projects the token is restricted to. The token may access all projects when unset",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
+				},
+			},
 			"secret": {
 				Type:      schema.TypeString,
 				Computed:  true,
@@ -57,10 +77,12 @@ func resourceAccessTokenRead(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	tokenId := d.Id()
 
-	ownerKey, _ := d.GetChange("owner")
-	owner, _ := strconv.Atoi(ownerKey.(string))
+	ownerID, err := accessTokenOwnerID(d, c)
+	if err != nil {
+		return err
+	}
 
-	token, err := c.GetAccessToken(owner, tokenId)
+	token, err := c.GetAccessToken(ownerID, tokenId)
 	if err != nil {
 		return err
 	}
@@ -69,37 +91,96 @@ func resourceAccessTokenRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
+	if token.Owner != nil {
+		ownerID = *token.Owner
+	}
+	if err := d.Set("owner_id", strconv.Itoa(ownerID)); err != nil {
+		return err
+	}
+	if token.Owner != nil {
+		owner, err := flattenOwner(d, c, *token.Owner)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("owner", owner); err != nil {
+			return err
+		}
+	}
+	if token.Projects != nil {
+		if err := d.Set("projects", identifierList(token.Projects)); err != nil {
+			return err
+		}
+	}
+
 	return err
 }
 
-func resourceAccessTokenCreate(d *schema.ResourceData, m interface{}) error {
+func resourceAccessTokenCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 	request := AccessToken{
 		Description: d.Get("description").(string),
 		Roles:       mapRolesToBackend(expandStringList(d.Get("roles").([]interface{}))),
+		Projects:    expandIdentifierList(d.Get("projects").([]interface{})),
+	}
+	owner, err := expandOwner(c, d.Get("owner").(string))
+	if err != nil {
+		return nil, err
 	}
-	owner, _ := strconv.Atoi(d.Get("owner").(string))
 
-	token, err := c.CreateAccessToken(owner, request)
+	token, warnings, err := c.CreateAccessToken(owner, request)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(token.ID)
 	d.Set("secret", token.Secret)
-	return err
+	return warnings, readAfterCreate(d, m, token.ID, resourceAccessTokenRead)
 }
 
 func resourceAccessTokenDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	tokenID := d.Id()
-	ownerKey, _ := d.GetChange("owner")
-	owner, _ := strconv.Atoi(ownerKey.(string))
+	ownerID, err := accessTokenOwnerID(d, c)
+	if err != nil {
+		return err
+	}
 
-	err := c.DeleteAccessToken(owner, tokenID)
+	err = c.DeleteAccessToken(ownerID, tokenID)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// Returns the ID of the token's owner. The ID kept in state is used when
+// there is one, so a token is still found after its owner is renamed.
+func accessTokenOwnerID(d *schema.ResourceData, c *Client) (int, error) {
+	if ownerID, _ := d.Get("owner_id").(string); ownerID != "" {
+		return strconv.Atoi(ownerID)
+	}
+	owner, _ := d.GetChange("owner")
+	return expandOwner(c, owner.(string))
+}
+
+// Imports an access token from an ID of the form owner/token, as a token
+// can only be found through its owner. The owner is a user's name or ID.
+func importAccessToken(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*Client)
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid access token ID %q. Import an access token using owner/token, where owner is the name or ID of the user who owns it", d.Id())
+	}
+
+	ownerID, err := expandOwner(c, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("owner", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("owner_id", strconv.Itoa(ownerID)); err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
@@ -2,7 +2,9 @@ package anaml
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -25,11 +27,11 @@ Multiple different types of attributes are supported:
 
 func ResourceAttributeRestriction() *schema.Resource {
 	return &schema.Resource{
-		Description: attributeDescription,
-		Create:      resourceAttributeRestrictionCreate,
-		Read:        resourceAttributeRestrictionRead,
-		Update:      resourceAttributeRestrictionUpdate,
-		Delete:      resourceAttributeRestrictionDelete,
+		Description:   attributeDescription,
+		CreateContext: withWarnings(resourceAttributeRestrictionCreate),
+		ReadContext:   withWarnings(resourceAttributeRestrictionRead),
+		UpdateContext: withWarnings(resourceAttributeRestrictionUpdate),
+		DeleteContext: withDiagnostics(resourceAttributeRestrictionDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -41,8 +43,9 @@ func ResourceAttributeRestriction() *schema.Resource {
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"mandatory": {
 				Type:     schema.TypeBool,
@@ -90,15 +93,13 @@ func ResourceAttributeRestriction() *schema.Resource {
 				Elem:     &schema.Resource{},
 			},
 			"applies_to": {
-				Type:     schema.TypeSet,
-				Required: true,
-				MinItems: 1,
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The kinds of object the attribute can be set on, any of: " + strings.Join(attributeTargetNames(), ", ") + ". This is a set, so the order given is not kept",
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
-					ValidateFunc: validation.StringInSlice([]string{
-						"cluster", "destination", "entity", "feature", "feature_set",
-						"feature_store", "feature_template", "source", "table",
-					}, false),
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(attributeTargetNames(), false),
 				},
 			},
 		},
@@ -127,108 +128,119 @@ func enumChoiceSchema() *schema.Resource {
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"display_emoji": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEmoji(),
 			},
 			"display_colour": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateColour(),
+				StateFunc:    normaliseColour,
 			},
 		},
 	}
 }
 
-func resourceAttributeRestrictionRead(d *schema.ResourceData, m interface{}) error {
+func resourceAttributeRestrictionRead(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 	attributeID := d.Id()
 
 	attribute, err := c.GetAttributeRestriction(attributeID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if attribute == nil {
 		d.SetId("")
-		return nil
+		return nil, nil
 	}
 
 	if err := d.Set("key", attribute.Key); err != nil {
-		return err
+		return nil, err
 	}
 	if err := d.Set("description", attribute.Description); err != nil {
-		return err
+		return nil, err
 	}
 	if err := d.Set("mandatory", attribute.Mandatory); err != nil {
-		return err
+		return nil, err
 	}
 	if err := d.Set("default_value", attribute.DefaultValue); err != nil {
-		return err
+		return nil, err
 	}
 
 	if attribute.Type == "enumattribute" {
 		e, err := parseEnumAttribute(attribute)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if err := d.Set("enum", e); err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		if err := d.Set("enum", nil); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	if err := d.Set("freetext", buildEmpty(attribute.Type == "freetextattribute")); err != nil {
-		return err
+		return nil, err
 	}
 	if err := d.Set("boolean", buildEmpty(attribute.Type == "booleanattribute")); err != nil {
-		return err
+		return nil, err
 	}
 	if err := d.Set("integer", buildEmpty(attribute.Type == "integerattribute")); err != nil {
-		return err
+		return nil, err
 	}
 	if err := d.Set("user", buildEmpty(attribute.Type == "userattribute")); err != nil {
-		return err
+		return nil, err
 	}
 	if err := d.Set("user_group", buildEmpty(attribute.Type == "usergroupattribute")); err != nil {
-		return err
+		return nil, err
 	}
-	if err := d.Set("applies_to", mapTargetsToFrontend(attribute.AppliesTo)); err != nil {
-		return err
+	appliesTo, unknown := mapTargetsToFrontend(attribute.AppliesTo)
+	if err := d.Set("applies_to", appliesTo); err != nil {
+		return nil, err
 	}
-	return err
+	return unknownTargetWarnings(attribute.Key, unknown), nil
 }
 
-func resourceAttributeRestrictionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceAttributeRestrictionCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 	attribute, err := composeAttribute(d)
 	if attribute == nil || err != nil {
-		return err
+		return nil, err
 	}
 
-	a, err := c.CreateAttributeRestriction(*attribute)
+	a, warnings, err := c.CreateAttributeRestriction(*attribute)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(a.ID))
-	return err
+	var readWarnings []string
+	err = readAfterCreate(d, m, strconv.Itoa(a.ID), func(d *schema.ResourceData, m interface{}) (err error) {
+		readWarnings, err = resourceAttributeRestrictionRead(d, m)
+		return err
+	})
+	return append(warnings, readWarnings...), err
 }
 
-func resourceAttributeRestrictionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceAttributeRestrictionUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	attributeID := d.Id()
 	attribute, err := composeAttribute(d)
 	if attribute == nil || err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateAttributeRestriction(attributeID, *attribute)
+	warnings, err := c.UpdateAttributeRestriction(attributeID, *attribute)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	readWarnings, err := resourceAttributeRestrictionRead(d, m)
+	return append(warnings, readWarnings...), err
 }
 
 func resourceAttributeRestrictionDelete(d *schema.ResourceData, m interface{}) error {
@@ -244,7 +256,10 @@ func resourceAttributeRestrictionDelete(d *schema.ResourceData, m interface{}) e
 }
 
 func composeAttribute(d *schema.ResourceData) (*AttributeRestriction, error) {
-	appliesTo := mapTargetsToBackend(expandStringList(d.Get("applies_to").(*schema.Set).List()))
+	appliesTo, err := mapTargetsToBackend(expandStringList(d.Get("applies_to").(*schema.Set).List()))
+	if err != nil {
+		return nil, err
+	}
 
 	attribute := AttributeRestriction{
 		Key:         d.Get("key").(string),
@@ -321,58 +336,71 @@ func parseNonEnumAttribute(attribute *AttributeRestriction) ([]map[string]interf
 	return neas, nil
 }
 
-func mapTargetsToFrontend(backend []AttributeTarget) []string {
+// The objects an attribute restriction can apply to, by the name used in
+// configuration and the name the server uses for the target.
+var attributeTargets = []struct {
+	frontend string
+	backend  string
+}{
+	{"cluster", "cluster"},
+	{"destination", "destination"},
+	{"entity", "entity"},
+	{"feature", "feature"},
+	{"feature_set", "featureset"},
+	{"feature_store", "featurestore"},
+	{"feature_template", "featuretemplate"},
+	{"source", "source"},
+	{"table", "table"},
+}
+
+func attributeTargetNames() []string {
+	names := make([]string, 0, len(attributeTargets))
+	for _, target := range attributeTargets {
+		names = append(names, target.frontend)
+	}
+	return names
+}
+
+// Maps the server's targets to the names used in configuration. Targets
+// this provider doesn't know, which a newer server may add, are left out
+// and returned separately so they can be warned about.
+func mapTargetsToFrontend(backend []AttributeTarget) ([]string, []string) {
 	vs := make([]string, 0, len(backend))
+	var unknown []string
+outer:
 	for _, v := range backend {
-		if v.Type == "cluster" {
-			vs = append(vs, "cluster")
-		} else if v.Type == "destination" {
-			vs = append(vs, "destination")
-		} else if v.Type == "entity" {
-			vs = append(vs, "entity")
-		} else if v.Type == "feature" {
-			vs = append(vs, "feature")
-		} else if v.Type == "featureset" {
-			vs = append(vs, "feature_set")
-		} else if v.Type == "featurestore" {
-			vs = append(vs, "feature_store")
-		} else if v.Type == "featuretemplate" {
-			vs = append(vs, "feature_template")
-		} else if v.Type == "source" {
-			vs = append(vs, "source")
-		} else if v.Type == "table" {
-			vs = append(vs, "table")
+		for _, target := range attributeTargets {
+			if v.Type == target.backend {
+				vs = append(vs, target.frontend)
+				continue outer
+			}
 		}
-		// TODO: We should raise an error if we fall through the cases.
+		unknown = append(unknown, v.Type)
 	}
-	return vs
+	return vs, unknown
 }
 
-func mapTargetsToBackend(frontend []string) []AttributeTarget {
+func unknownTargetWarnings(key string, unknown []string) []string {
+	warnings := make([]string, 0, len(unknown))
+	for _, target := range unknown {
+		warnings = append(warnings, fmt.Sprintf("Attribute restriction %s applies to %q, which this provider doesn't know, so it is left out of applies_to. Updating the restriction will remove it", key, target))
+	}
+	return warnings
+}
+
+func mapTargetsToBackend(frontend []string) ([]AttributeTarget, error) {
 	vs := make([]AttributeTarget, 0, len(frontend))
+outer:
 	for _, v := range frontend {
-		if v == "cluster" {
-			vs = append(vs, AttributeTarget{"cluster"})
-		} else if v == "destination" {
-			vs = append(vs, AttributeTarget{"destination"})
-		} else if v == "entity" {
-			vs = append(vs, AttributeTarget{"entity"})
-		} else if v == "feature" {
-			vs = append(vs, AttributeTarget{"feature"})
-		} else if v == "feature_set" {
-			vs = append(vs, AttributeTarget{"featureset"})
-		} else if v == "feature_store" {
-			vs = append(vs, AttributeTarget{"featurestore"})
-		} else if v == "feature_template" {
-			vs = append(vs, AttributeTarget{"featuretemplate"})
-		} else if v == "source" {
-			vs = append(vs, AttributeTarget{"source"})
-		} else if v == "table" {
-			vs = append(vs, AttributeTarget{"table"})
+		for _, target := range attributeTargets {
+			if v == target.frontend {
+				vs = append(vs, AttributeTarget{target.backend})
+				continue outer
+			}
 		}
-		// TODO: We should raise an error if we fall through the cases.
+		return nil, fmt.Errorf("Unknown attribute restriction target %q, expected one of: %s", v, strings.Join(attributeTargetNames(), ", "))
 	}
-	return vs
+	return vs, nil
 }
 
 func expandEnumChoices(choices []interface{}) ([]EnumAttributeChoice, error) {
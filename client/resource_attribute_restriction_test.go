@@ -0,0 +1,84 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMapTargetsToBackend(t *testing.T) {
+	cases := []struct {
+		frontend []string
+		want     []AttributeTarget
+		wantErr  bool
+	}{
+		{[]string{"feature_set", "table"}, []AttributeTarget{{"featureset"}, {"table"}}, false},
+		{[]string{"feature_store", "feature_template"}, []AttributeTarget{{"featurestore"}, {"featuretemplate"}}, false},
+		{[]string{"featureset"}, nil, true},
+		{[]string{"table", "view"}, nil, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(strings.Join(tt.frontend, ","), func(t *testing.T) {
+			got, err := mapTargetsToBackend(tt.frontend)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "feature_set") {
+					t.Fatalf("err = %v, want an error listing the valid targets", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapTargetsToFrontend(t *testing.T) {
+	cases := []struct {
+		name        string
+		backend     []AttributeTarget
+		want        []string
+		wantUnknown []string
+	}{
+		{"known", []AttributeTarget{{"featureset"}, {"source"}}, []string{"feature_set", "source"}, nil},
+		{"unknown", []AttributeTarget{{"table"}, {"dashboard"}, {"report"}}, []string{"table"}, []string{"dashboard", "report"}},
+		{"empty", nil, []string{}, nil},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unknown := mapTargetsToFrontend(tt.backend)
+			if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(unknown, tt.wantUnknown) {
+				t.Errorf("got %v, %v, want %v, %v", got, unknown, tt.want, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestAttributeRestrictionsReadWarnsOnUnknownTargets(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": 1, "key": "owner", "description": "", "adt_type": "freetextattribute", "mandatory": false,
+			"appliesTo": [{"adt_type": "table"}, {"adt_type": "dashboard"}]}]`))
+	})
+
+	d := schema.TestResourceDataRaw(t, DataSourceAttributeRestrictions().Schema, map[string]interface{}{})
+	diags := DataSourceAttributeRestrictions().ReadContext(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, `"dashboard"`) {
+		t.Errorf("diags = %v, want a warning about dashboard", diags)
+	}
+	if got := d.Get("attribute_restrictions.0.applies_to"); !reflect.DeepEqual(got, []interface{}{"table"}) {
+		t.Errorf("applies_to = %v, want [table]", got)
+	}
+}
@@ -20,13 +20,13 @@ With Branch Protections you can:
 
 func ResourceBranchProtection() *schema.Resource {
 	return &schema.Resource{
-		Description: protectionDesc,
-		Create:      resourceBranchProtectionCreate,
-		Read:        resourceBranchProtectionRead,
-		Update:      resourceBranchProtectionUpdate,
-		Delete:      resourceBranchProtectionDelete,
+		Description:   protectionDesc,
+		CreateContext: withWarnings(resourceBranchProtectionCreate),
+		ReadContext:   withDiagnostics(resourceBranchProtectionRead),
+		UpdateContext: withWarnings(resourceBranchProtectionUpdate),
+		DeleteContext: withDiagnostics(resourceBranchProtectionDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("branch protection", (*Client).FindBranchProtection),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -188,36 +188,37 @@ func resourceBranchProtectionRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceBranchProtectionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceBranchProtectionCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 	BranchProtection, err := composeBranchProtection(d)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateBranchProtection(*BranchProtection)
+	e, warnings, err := c.CreateBranchProtection(*BranchProtection)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceBranchProtectionRead)
 }
 
-func resourceBranchProtectionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceBranchProtectionUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	BranchProtectionID := d.Id()
 	BranchProtection, err := composeBranchProtection(d)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateBranchProtection(BranchProtectionID, *BranchProtection)
+	warnings, err := c.UpdateBranchProtection(BranchProtectionID, *BranchProtection)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceBranchProtectionRead(d, m)
 }
 
 func resourceBranchProtectionDelete(d *schema.ResourceData, m interface{}) error {
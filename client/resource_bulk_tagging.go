@@ -0,0 +1,240 @@
+package anaml
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const bulkTaggingDescription = `# Bulk Tagging
+
+Applies labels and attributes to every object of a type which carries a
+set of labels, such as every feature labelled "pii". Objects which start
+to match, or lose the tags, are tagged again on the next apply.
+
+Tags are only ever added, and are left in place when the resource is
+destroyed. Objects which are also managed by their own resource should
+configure the same tags there, otherwise the two will undo each other.
+`
+
+var taggableEndpoints = map[string]string{
+	"cluster":          "cluster",
+	"destination":      "destination",
+	"entity":           "entity",
+	"feature":          "feature",
+	"feature_set":      "feature-set",
+	"feature_store":    "feature-store",
+	"feature_template": "feature-template",
+	"source":           "source",
+	"table":            "table",
+}
+
+func ResourceBulkTagging() *schema.Resource {
+	objectTypes := make([]string, 0, len(taggableEndpoints))
+	for objectType := range taggableEndpoints {
+		objectTypes = append(objectTypes, objectType)
+	}
+	sort.Strings(objectTypes)
+
+	return &schema.Resource{
+		Description:   bulkTaggingDescription,
+		CreateContext: withWarnings(resourceBulkTaggingCreate),
+		ReadContext:   withDiagnostics(resourceBulkTaggingRead),
+		UpdateContext: withWarnings(resourceBulkTaggingUpdate),
+		DeleteContext: withDiagnostics(resourceBulkTaggingDelete),
+
+		Schema: map[string]*schema.Schema{
+			"object_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of object to tag, one of: " + strings.Join(objectTypes, ", "),
+				ValidateFunc: validation.StringInSlice(objectTypes, false),
+			},
+			"label_filter": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "Only objects which carry all of these labels are tagged",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"labels": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "Labels to attach to the matching objects",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"labels", "attribute"},
+			},
+			"attribute": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Attributes (key value pairs) to attach to the matching objects",
+				Elem:        attributeSchema(),
+			},
+			"objects_matched": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How many objects matched the filter when last read",
+			},
+			"objects_updated": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How many objects were changed by the last apply",
+			},
+		},
+	}
+}
+
+// Reads back the configured labels and attributes which every matching
+// object carries. Any which are missing from some object show as a diff,
+// so the next apply tags those objects again.
+func resourceBulkTaggingRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+
+	objects, err := c.ListTaggedObjects(taggableEndpoints[d.Get("object_type").(string)], expandStringList(d.Get("label_filter").(*schema.Set).List()))
+	if err != nil {
+		return err
+	}
+
+	labels := make([]string, 0)
+	for _, label := range expandStringList(d.Get("labels").(*schema.Set).List()) {
+		carried := true
+		for _, object := range objects {
+			if !hasLabel(c, object.Labels, label) {
+				carried = false
+				break
+			}
+		}
+		if carried {
+			labels = append(labels, label)
+		}
+	}
+
+	attributes := make([]Attribute, 0)
+	for _, attribute := range expandAttributesFromInterfaces(d.Get("attribute").(*schema.Set).List()) {
+		carried := true
+		for _, object := range objects {
+			if !hasAttribute(object.Attributes, attribute) {
+				carried = false
+				break
+			}
+		}
+		if carried {
+			attributes = append(attributes, attribute)
+		}
+	}
+
+	if err := d.Set("labels", labels); err != nil {
+		return err
+	}
+	if err := d.Set("attribute", flattenAttributes(attributes)); err != nil {
+		return err
+	}
+	if err := d.Set("objects_matched", len(objects)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceBulkTaggingCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
+	warnings, err := applyBulkTagging(d, m.(*Client))
+	if err != nil {
+		return warnings, err
+	}
+
+	filter := expandStringList(d.Get("label_filter").(*schema.Set).List())
+	sort.Strings(filter)
+	d.SetId(d.Get("object_type").(string) + ":" + strings.Join(filter, ","))
+	return warnings, resourceBulkTaggingRead(d, m)
+}
+
+func resourceBulkTaggingUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
+	warnings, err := applyBulkTagging(d, m.(*Client))
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, resourceBulkTaggingRead(d, m)
+}
+
+// Tags are left on the objects, as they may have been carried before
+// the resource was created.
+func resourceBulkTaggingDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+// Adds the configured labels and attributes to every matching object
+// which is missing any of them. Objects which already carry them all
+// are left alone. Returns the warnings the server gave for the updates.
+func applyBulkTagging(d *schema.ResourceData, c *Client) ([]string, error) {
+	endpoint := taggableEndpoints[d.Get("object_type").(string)]
+	objects, err := c.ListTaggedObjects(endpoint, expandStringList(d.Get("label_filter").(*schema.Set).List()))
+	if err != nil {
+		return nil, err
+	}
+
+	labels := expandStringList(d.Get("labels").(*schema.Set).List())
+	attributes := expandAttributesFromInterfaces(d.Get("attribute").(*schema.Set).List())
+
+	var warnings []string
+	updated := 0
+	for _, object := range objects {
+		changed := false
+		for _, label := range labels {
+			if !hasLabel(c, object.Labels, label) {
+				object.Labels = append(object.Labels, c.labelKey(label))
+				changed = true
+			}
+		}
+		for _, attribute := range attributes {
+			if hasAttribute(object.Attributes, attribute) {
+				continue
+			}
+			replaced := false
+			for i := range object.Attributes {
+				if object.Attributes[i].Key == attribute.Key {
+					object.Attributes[i].Value = attribute.Value
+					replaced = true
+				}
+			}
+			if !replaced {
+				object.Attributes = append(object.Attributes, attribute)
+			}
+			changed = true
+		}
+
+		if changed {
+			objectWarnings, err := c.UpdateTaggedObject(endpoint, object)
+			warnings = append(warnings, objectWarnings...)
+			if err != nil {
+				return warnings, err
+			}
+			updated++
+		}
+	}
+
+	return warnings, d.Set("objects_updated", updated)
+}
+
+func hasLabel(c *Client, labels []string, label string) bool {
+	for _, carried := range labels {
+		if c.labelKey(carried) == c.labelKey(label) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAttribute(attributes []Attribute, attribute Attribute) bool {
+	for _, carried := range attributes {
+		if carried == attribute {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package anaml
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -38,16 +39,17 @@ Anaml Spark Server Clusters can be:
 
 func ResourceCluster() *schema.Resource {
 	return &schema.Resource{
-		Description: clusterDesc,
-		Create:      resourceClusterCreate,
-		Read:        resourceClusterRead,
-		Update:      resourceClusterUpdate,
-		Delete:      resourceClusterDelete,
+		Description:   clusterDesc,
+		CreateContext: withWarnings(resourceClusterCreate),
+		ReadContext:   withDiagnostics(resourceClusterRead),
+		UpdateContext: withWarnings(resourceClusterUpdate),
+		DeleteContext: withDiagnostics(resourceClusterDelete),
+		CustomizeDiff: customizeClusterDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("cluster", (*Client).FindCluster),
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: auditSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Description:  "The name of the cluster.",
@@ -55,8 +57,9 @@ func ResourceCluster() *schema.Resource {
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"is_preview_cluster": {
 				Type:        schema.TypeBool,
@@ -107,20 +110,40 @@ func ResourceCluster() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
-		},
+			"deletion_protection": deletionProtectionSchema(),
+		}),
 	}
 }
 
+func customizeClusterDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.NewValueKnown("spark_server.0.min_workers") && d.NewValueKnown("spark_server.0.max_workers") {
+		minWorkers, _ := d.Get("spark_server.0.min_workers").(int)
+		maxWorkers, _ := d.Get("spark_server.0.max_workers").(int)
+		if minWorkers != 0 && maxWorkers != 0 && minWorkers > maxWorkers {
+			return fmt.Errorf("min_workers (%d) can't be more than max_workers (%d)", minWorkers, maxWorkers)
+		}
+	}
+	if !d.NewValueKnown("spark_config.0.enable_hive_support") || !d.NewValueKnown("spark_config.0.hive_metastore_url") {
+		return nil
+	}
+	if d.Get("spark_config.0.hive_metastore_url").(string) != "" && !d.Get("spark_config.0.enable_hive_support").(bool) {
+		return errors.New("hive_metastore_url can only be set when enable_hive_support is true")
+	}
+	return nil
+}
+
 func sparkConfigSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"enable_hive_support": {
-				Type:     schema.TypeBool,
-				Required: true,
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether Spark is run with Hive support enabled.",
 			},
 			"hive_metastore_url": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL of the Hive metastore. Only valid when enable_hive_support is true.",
 			},
 			"additional_spark_properties": {
 				Type: schema.TypeMap,
@@ -185,6 +208,24 @@ func sparkServerSchema() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
+			"min_workers": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The fewest workers the cluster scales down to. Unset uses the Spark Server's default",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_workers": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The most workers the cluster scales up to. Unset uses the Spark Server's default",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"node_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The machine type of the cluster's workers. Unset uses the Spark Server's default",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
 		},
 	}
 }
@@ -216,10 +257,28 @@ func loginCredentialsProviderConfigSchema() *schema.Resource {
 				MaxItems: 1,
 				Elem:     gcpCredentialsProviderConfigSchema(),
 			},
+			"credential_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An opaque version marker for the credentials, not the secret itself. Changing it pushes the configuration to the server again, so that rotated secrets are resolved afresh.",
+			},
 		},
 	}
 }
 
+// Snowflake also accepts an OAuth bearer token in place of a username and
+// password.
+func snowflakeCredentialsProviderConfigSchema() *schema.Resource {
+	provider := loginCredentialsProviderConfigSchema()
+	provider.Schema["oauth"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem:     oauthCredentialsProviderConfigSchema(),
+	}
+	return provider
+}
+
 func basicCredentialsProviderConfigSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -238,6 +297,33 @@ func basicCredentialsProviderConfigSchema() *schema.Resource {
 	}
 }
 
+func oauthCredentialsProviderConfigSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"token": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Sensitive:   true,
+				Description: "Where to find the OAuth bearer token. It is never read back from the server",
+				Elem:        secretValueConfigSchema(),
+			},
+			"token_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The endpoint the server refreshes the token from",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"client_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The OAuth client ID used to refresh the token",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+		},
+	}
+}
+
 func fileCredentialsProviderConfigSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -333,6 +419,7 @@ func resourceClusterRead(d *schema.ResourceData, m interface{}) error {
 		if local == nil || err != nil {
 			return err
 		}
+		setCredentialVersion(d, "local", local)
 		if err := d.Set("local", local); err != nil {
 			return err
 		}
@@ -348,53 +435,62 @@ func resourceClusterRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	if err := d.Set("labels", cluster.Labels); err != nil {
+	if err := setAuditMetadata(d, cluster.AuditMetadata); err != nil {
+		return err
+	}
+	if err := d.Set("deletion_protection", d.Get("deletion_protection").(bool)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(cluster.Attributes)); err != nil {
+	if err := d.Set("labels", flattenLabels(d, c, cluster.Labels)); err != nil {
+		return err
+	}
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, cluster.Attributes))); err != nil {
 		return err
 	}
 	return err
 }
 
-func resourceClusterCreate(d *schema.ResourceData, m interface{}) error {
+func resourceClusterCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	cluster, err := composeCluster(d)
+	cluster, err := composeCluster(d, c)
 	if cluster == nil || err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateCluster(*cluster)
+	e, warnings, err := c.CreateCluster(*cluster)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	resourceClusterRead(d, m)
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceClusterRead)
 }
 
-func resourceClusterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceClusterUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	clusterID := d.Id()
-	cluster, err := composeCluster(d)
+	cluster, err := composeCluster(d, c)
 	if cluster == nil || err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateCluster(clusterID, *cluster)
+	warnings, err := c.UpdateCluster(clusterID, *cluster)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	resourceClusterRead(d, m)
-	return nil
+	d.Partial(false)
+	return warnings, resourceClusterRead(d, m)
 }
 
 func resourceClusterDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	clusterID := d.Id()
 
+	if err := checkDeletionProtection(d, "Cluster"); err != nil {
+		return err
+	}
+
 	err := c.DeleteCluster(clusterID)
 	if err != nil {
 		return err
@@ -419,6 +515,40 @@ func parseLocal(cluster *Cluster) ([]map[string]interface{}, error) {
 	return locals, nil
 }
 
+// The credential version is only used to trigger an update and is never
+// sent to the server, so it's carried over from the existing state.
+// Takes the schema key of the block holding the credentials and its
+// parsed value, where the credentials are either nested within a
+// "credentials_provider" block or inlined (as for local clusters).
+func setCredentialVersion(d *schema.ResourceData, key string, parsed []map[string]interface{}) {
+	if len(parsed) == 0 {
+		return
+	}
+	if providers, ok := parsed[0]["credentials_provider"].([]map[string]interface{}); ok {
+		if len(providers) > 0 {
+			providers[0]["credential_version"] = d.Get(key + ".0.credentials_provider.0.credential_version")
+		}
+		return
+	}
+	parsed[0]["credential_version"] = d.Get(key + ".0.credential_version")
+}
+
+// OAuth tokens are never read back from the server, so the configured
+// token is carried over from the existing state. Takes the schema key of
+// the block holding the "credentials_provider" block and its parsed value.
+func setOAuthToken(d *schema.ResourceData, key string, parsed []map[string]interface{}) {
+	if len(parsed) == 0 {
+		return
+	}
+	providers, ok := parsed[0]["credentials_provider"].([]map[string]interface{})
+	if !ok || len(providers) == 0 {
+		return
+	}
+	if oauths, ok := providers[0]["oauth"].([]map[string]interface{}); ok && len(oauths) > 0 {
+		oauths[0]["token"] = d.Get(key + ".0.credentials_provider.0.oauth.0.token")
+	}
+}
+
 func parseLoginCredentialsProviderConfig(credentials *LoginCredentialsProviderConfig) (map[string]interface{}, error) {
 	if credentials == nil {
 		return nil, errors.New("LoginCredentialsProviderConfig is null")
@@ -459,6 +589,15 @@ func parseLoginCredentialsProviderConfig(credentials *LoginCredentialsProviderCo
 		gcps := make([]map[string]interface{}, 0, 1)
 		gcps = append(gcps, gcp)
 		provider["gcp"] = gcps
+	} else if credentials.Type == "oauth" {
+		// The token is never read back, see setOAuthToken.
+		oauth := make(map[string]interface{})
+		oauth["token_endpoint"] = credentials.TokenEndpoint
+		oauth["client_id"] = credentials.ClientId
+
+		oauths := make([]map[string]interface{}, 0, 1)
+		oauths = append(oauths, oauth)
+		provider["oauth"] = oauths
 	} else {
 		return nil, fmt.Errorf("LoginCredentialsProviderConfig.Type contains an unexpected value: %s", credentials.Type)
 	}
@@ -473,6 +612,13 @@ func parseSparkServer(cluster *Cluster) ([]map[string]interface{}, error) {
 
 	sparkServer := make(map[string]interface{})
 	sparkServer["spark_server_url"] = cluster.SparkServerURL
+	if cluster.MinWorkers != nil {
+		sparkServer["min_workers"] = *cluster.MinWorkers
+	}
+	if cluster.MaxWorkers != nil {
+		sparkServer["max_workers"] = *cluster.MaxWorkers
+	}
+	sparkServer["node_type"] = cluster.NodeType
 
 	sparkServers := make([]map[string]interface{}, 0, 1)
 	sparkServers = append(sparkServers, sparkServer)
@@ -487,14 +633,19 @@ func parseSparkConfig(config *SparkConfig) ([]map[string]interface{}, error) {
 	sparkConfig := make(map[string]interface{})
 	sparkConfig["enable_hive_support"] = config.EnableHiveSupport
 	sparkConfig["hive_metastore_url"] = config.HiveMetastoreURL
-	sparkConfig["additional_spark_properties"] = config.AdditionalSparkProperties
+	// A missing map is read back as an empty one, matching the default.
+	additionalSparkProperties := config.AdditionalSparkProperties
+	if additionalSparkProperties == nil {
+		additionalSparkProperties = make(map[string]string)
+	}
+	sparkConfig["additional_spark_properties"] = additionalSparkProperties
 
 	sparkConfigs := make([]map[string]interface{}, 0, 1)
 	sparkConfigs = append(sparkConfigs, sparkConfig)
 	return sparkConfigs, nil
 }
 
-func composeCluster(d *schema.ResourceData) (*Cluster, error) {
+func composeCluster(d *schema.ResourceData, c *Client) (*Cluster, error) {
 	sparkConfigMap, err := expandSingleMap(d.Get("spark_config"))
 	if err != nil {
 		return nil, err
@@ -516,8 +667,8 @@ func composeCluster(d *schema.ResourceData) (*Cluster, error) {
 			CredentialsProvider: credentialsProvider,
 			SparkConfig:         &sparkConfig,
 			PropertySet:         expandPropertySet(d),
-			Labels:              expandLabels(d),
-			Attributes:          expandAttributes(d),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
 		}
 		return &cluster, nil
 	}
@@ -531,9 +682,16 @@ func composeCluster(d *schema.ResourceData) (*Cluster, error) {
 			SparkServerURL:   sparkServer["spark_server_url"].(string),
 			SparkConfig:      &sparkConfig,
 			PropertySet:      expandPropertySet(d),
-			Labels:           expandLabels(d),
-			Attributes:       expandAttributes(d),
+			Labels:           expandLabels(d, c),
+			Attributes:       expandAttributes(d, c),
 		}
+		if minWorkers, _ := sparkServer["min_workers"].(int); minWorkers != 0 {
+			cluster.MinWorkers = &minWorkers
+		}
+		if maxWorkers, _ := sparkServer["max_workers"].(int); maxWorkers != 0 {
+			cluster.MaxWorkers = &maxWorkers
+		}
+		cluster.NodeType = sparkServer["node_type"].(string)
 		return &cluster, nil
 	}
 
@@ -578,6 +736,24 @@ func composeLoginCredentialsProviderConfig(d map[string]interface{}) (*LoginCred
 		return &provider, nil
 	}
 
+	if oauth, _ := expandSingleMap(d["oauth"]); oauth != nil {
+		token, _ := expandSingleMap(oauth["token"])
+		if token == nil {
+			return nil, errors.New("OAuth credentials have no token")
+		}
+		tokenConfig, err := composeSecretValueConfig(token)
+		if err != nil {
+			return nil, err
+		}
+		provider := LoginCredentialsProviderConfig{
+			Type:          "oauth",
+			Token:         tokenConfig,
+			TokenEndpoint: oauth["token_endpoint"].(string),
+			ClientId:      oauth["client_id"].(string),
+		}
+		return &provider, nil
+	}
+
 	return nil, errors.New("Invalid login credentials provider config type")
 }
 
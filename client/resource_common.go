@@ -4,8 +4,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func labelSchema() *schema.Schema {
@@ -14,8 +19,337 @@ func labelSchema() *schema.Schema {
 	}
 }
 
-func expandLabels(d *schema.ResourceData) []string {
-	return expandStringList(d.Get("labels").(*schema.Set).List())
+// The labels argument of list data sources, which only return objects
+// carrying all of the given labels.
+func labelFilterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Only return objects which carry all of these labels",
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+	}
+}
+
+// Labels are the resource's own labels merged with the provider's
+// default labels.
+func expandLabels(d *schema.ResourceData, c *Client) []string {
+	configured := expandStringList(d.Get("labels").(*schema.Set).List())
+	labels := make([]string, 0, len(configured)+len(c.DefaultLabels))
+	seen := make(map[string]bool, len(configured))
+	for _, label := range append(configured, c.DefaultLabels...) {
+		label = c.labelKey(label)
+		if !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// Removes the provider's default labels from those read from the server,
+// unless the resource configures them itself. This stops the defaults
+// from showing as a diff, and means a label which is no longer a default
+// is read back and removed on the next apply. When labels are case
+// insensitive, a label is read back as it is written in the configuration.
+func flattenLabels(d *schema.ResourceData, c *Client, labels []string) []string {
+	defaults := make(map[string]bool, len(c.DefaultLabels))
+	for _, label := range c.DefaultLabels {
+		defaults[c.labelKey(label)] = true
+	}
+	configured := make(map[string]string)
+	for _, label := range expandStringList(d.Get("labels").(*schema.Set).List()) {
+		configured[c.labelKey(label)] = label
+	}
+
+	res := make([]string, 0, len(labels))
+	for _, label := range labels {
+		key := c.labelKey(label)
+		written, isConfigured := configured[key]
+		if defaults[key] && !isConfigured {
+			continue
+		}
+		if isConfigured {
+			label = written
+		}
+		res = append(res, label)
+	}
+	return res
+}
+
+// Resolves a principal reference to the ID of a user or user group. A
+// reference is either a numeric ID, a user's name prefixed with "user:",
+// or a user group's name prefixed with "group:". A name without a prefix
+// is taken to be a user's. Returns false if no user or group has the name.
+func findPrincipal(c *Client, ref string) (int, bool, error) {
+	if identifierPattern.MatchString(ref) {
+		id, err := strconv.Atoi(ref)
+		return id, err == nil, err
+	}
+	if strings.HasPrefix(ref, "group:") {
+		group, err := c.FindUserGroup(strings.TrimPrefix(ref, "group:"))
+		if err != nil || group == nil {
+			return 0, false, err
+		}
+		return group.ID, true, nil
+	}
+	user, err := c.FindUser(strings.TrimPrefix(ref, "user:"))
+	if err != nil || user == nil {
+		return 0, false, err
+	}
+	return user.ID, true, nil
+}
+
+// Returns the ID of the principal referenced by key, or nil if key is
+// not set.
+func resolvePrincipal(c *Client, d *schema.ResourceData, key string) (*int, error) {
+	ref, ok := d.GetOk(key)
+	if !ok {
+		return nil, nil
+	}
+	id, found, err := findPrincipal(c, ref.(string))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("No user or group %s found for %s", ref, key)
+	}
+	return &id, nil
+}
+
+// Returns the principal to store in state under key. If the configured
+// reference still resolves to the principal read from the server it is
+// kept as written, so that naming the principal doesn't show as a diff.
+func flattenPrincipal(d *schema.ResourceData, c *Client, key string, id int) (string, error) {
+	if current, ok := d.Get(key).(string); ok && current != "" {
+		resolved, found, err := findPrincipal(c, current)
+		if err != nil {
+			return "", err
+		}
+		if found && resolved == id {
+			return current, nil
+		}
+	}
+	return strconv.Itoa(id), nil
+}
+
+// Looks up the user an owner reference names. The reference is a user's
+// name or ID, optionally prefixed with "user:". Only users own objects,
+// so a "group:" reference is an error rather than a principal lookup.
+func findOwner(c *Client, owner string) (*User, error) {
+	if strings.HasPrefix(owner, "group:") {
+		return nil, fmt.Errorf("Owner %s is a group, but only a user can be an owner", owner)
+	}
+	return c.FindUser(strings.TrimPrefix(owner, "user:"))
+}
+
+// Resolves an owner reference to the user's ID.
+func expandOwner(c *Client, owner string) (int, error) {
+	user, err := findOwner(c, owner)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		return 0, fmt.Errorf("User %s not found", owner)
+	}
+	return user.ID, nil
+}
+
+// Returns the owner to store in state, keeping the configured reference
+// while it still resolves to the owner read from the server.
+func flattenOwner(d *schema.ResourceData, c *Client, owner int) (string, error) {
+	if current, ok := d.Get("owner").(string); ok && current != "" {
+		user, err := findOwner(c, current)
+		if err != nil {
+			return "", err
+		}
+		if user != nil && user.ID == owner {
+			return current, nil
+		}
+	}
+	return strconv.Itoa(owner), nil
+}
+
+// Returns the ID of the provider's default cluster, which may be given as
+// either a name or an ID. Returns false if there is no default.
+func defaultCluster(c *Client) (int, bool, error) {
+	if c.DefaultCluster == "" {
+		return 0, false, nil
+	}
+	if identifierPattern.MatchString(c.DefaultCluster) {
+		id, err := strconv.Atoi(c.DefaultCluster)
+		return id, err == nil, err
+	}
+	cluster, err := c.FindCluster(c.DefaultCluster)
+	if err != nil {
+		return 0, false, err
+	}
+	if cluster == nil {
+		return 0, false, fmt.Errorf("Default cluster %s not found", c.DefaultCluster)
+	}
+	return cluster.ID, true, nil
+}
+
+// Returns the ID of the cluster a job runs on, which is the provider's
+// default cluster when the resource doesn't set one.
+func expandCluster(d *schema.ResourceData, c *Client) (int, error) {
+	if cluster := d.Get("cluster").(string); cluster != "" {
+		return strconv.Atoi(cluster)
+	}
+	id, found, err := defaultCluster(c)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, errors.New("No cluster is set, and the provider has no default_cluster")
+	}
+	return id, nil
+}
+
+// Returns the cluster to store in state. A job which doesn't set a
+// cluster and runs on the default cluster is left without one, so that
+// changing the default shows as a diff.
+func flattenCluster(d *schema.ResourceData, c *Client, cluster int) (string, error) {
+	if d.Get("cluster").(string) == "" {
+		id, found, err := defaultCluster(c)
+		if err != nil {
+			return "", err
+		}
+		if found && id == cluster {
+			return "", nil
+		}
+	}
+	return strconv.Itoa(cluster), nil
+}
+
+// How long to wait for a newly created object to be readable. The server
+// may briefly return 404 for an object it has only just created.
+const createPropagationTimeout = 5 * time.Second
+
+// The longest delay between two checks made by poll.
+const maxPollDelay = 30 * time.Second
+
+// Returned by poll when check hasn't finished before the timeout.
+var errPollTimeout = errors.New("timed out")
+
+// Calls check until it reports that it's done, starting with the given
+// delay between calls and doubling it each time, up to maxPollDelay. Gives
+// up with errPollTimeout once the next call would be after the timeout.
+func poll(delay, timeout time.Duration, check func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+		if time.Now().Add(delay).After(deadline) {
+			return errPollTimeout
+		}
+		time.Sleep(delay)
+		if delay *= 2; delay > maxPollDelay {
+			delay = maxPollDelay
+		}
+	}
+}
+
+// Sets the ID of a newly created object and reads it back into state. A
+// read which finds the object missing is retried, with a growing delay,
+// until createPropagationTimeout has passed. If it is still missing the
+// ID is kept, so that Terraform taints the object rather than losing it.
+func readAfterCreate(d *schema.ResourceData, m interface{}, id string, read schema.ReadFunc) error {
+	err := poll(250*time.Millisecond, createPropagationTimeout, func() (bool, error) {
+		d.SetId(id)
+		if err := read(d, m); err != nil {
+			return false, err
+		}
+		return d.Id() != "", nil
+	})
+	if err == errPollTimeout {
+		d.SetId(id)
+		return fmt.Errorf("Object %s was created but could not be read back", id)
+	}
+	return err
+}
+
+// Returns an import function which accepts either an object's numeric ID
+// or its name. find is the Client method which looks up an object by name,
+// such as (*Client).FindEntityByName. It returns a pointer to the object,
+// which has an integer ID field, or nil when nothing has the name.
+func importByIDOrName(kind string, find interface{}) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		id := d.Id()
+		if identifierPattern.MatchString(id) {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		c := m.(*Client)
+		out := reflect.ValueOf(find).Call([]reflect.Value{reflect.ValueOf(c), reflect.ValueOf(id)})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, err
+		}
+		if out[0].IsNil() {
+			return nil, fmt.Errorf("No %s found with the name %q. Import a %s using either its numeric ID or its name", kind, id, kind)
+		}
+
+		d.SetId(strconv.Itoa(int(out[0].Elem().FieldByName("ID").Int())))
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// Ignores differences in leading and trailing whitespace, which the
+// server may trim from free text such as descriptions.
+func suppressWhitespaceDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+func auditSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["created_at"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "When the object was created",
+	}
+	s["updated_at"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "When the object was last changed",
+	}
+	s["version"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The object's current version",
+	}
+	return s
+}
+
+func setAuditMetadata(d *schema.ResourceData, metadata AuditMetadata) error {
+	if err := d.Set("created_at", metadata.CreatedAt); err != nil {
+		return err
+	}
+	if err := d.Set("updated_at", metadata.UpdatedAt); err != nil {
+		return err
+	}
+	return d.Set("version", string(metadata.Version))
+}
+
+func deletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the provider refuses to delete the object. Set to false and apply before destroying it",
+	}
+}
+
+// Returns an error when the object has deletion protection enabled. This
+// is checked by the provider itself, so it also holds when the object is
+// removed from the configuration or destroyed with terraform destroy.
+func checkDeletionProtection(d *schema.ResourceData, kind string) error {
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("%s %s has deletion protection enabled. Set deletion_protection to false and apply before deleting it", kind, d.Id())
+	}
+	return nil
 }
 
 func attributeSchema() *schema.Resource {
@@ -39,7 +373,8 @@ func destinationSchema() *schema.Resource {
 			"destination": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Description:  "The Destination's identifier, or its name. A name is resolved to an ID when applying and when refreshing, so the same configuration can refer to a different Destination in each environment",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"folder": {
 				Type:     schema.TypeList,
@@ -60,10 +395,18 @@ func destinationSchema() *schema.Resource {
 				Elem:     topicDestinationSchema(),
 			},
 			"option": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Attributes (key value pairs) to attach to the object",
-				Elem:        attributeSchema(),
+				Type:             schema.TypeSet,
+				Optional:         true,
+				Description:      "Save options (key value pairs) to pass to the engine when writing to the destination",
+				Elem:             attributeSchema(),
+				DiffSuppressFunc: suppressDestinationOptionsDiff,
+			},
+			"options": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Description:      "Save options to pass to the engine when writing to the destination, as a map. A key can't also be given as an option block",
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				DiffSuppressFunc: suppressDestinationOptionsDiff,
 			},
 		},
 	}
@@ -79,7 +422,10 @@ func folderDestinationSchema() *schema.Resource {
 			},
 			"partitioning_enabled": {
 				Type:     schema.TypeBool,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				Description: "Whether the output is partitioned into folders. Left unset, the server's default is used and kept in " +
+					"state, so removing the setting later keeps the current value rather than reverting to the default",
 			},
 			"save_mode": {
 				Type:     schema.TypeString,
@@ -130,9 +476,47 @@ func topicDestinationSchema() *schema.Resource {
 	}
 }
 
-func expandAttributes(d *schema.ResourceData) []Attribute {
+// Attributes are the resource's own attributes merged with the provider's
+// default attributes. The resource's attributes win when keys conflict.
+func expandAttributes(d *schema.ResourceData, c *Client) []Attribute {
 	drs := d.Get("attribute").(*schema.Set).List()
-	return expandAttributesFromInterfaces(drs)
+	attributes := expandAttributesFromInterfaces(drs)
+
+	configured := make(map[string]bool, len(attributes))
+	for _, attribute := range attributes {
+		configured[attribute.Key] = true
+	}
+
+	keys := make([]string, 0, len(c.DefaultAttributes))
+	for key := range c.DefaultAttributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !configured[key] {
+			attributes = append(attributes, Attribute{Key: key, Value: c.DefaultAttributes[key]})
+		}
+	}
+	return attributes
+}
+
+// Removes the provider's default attributes from those read from the
+// server, unless the resource configures the same key itself.
+func omitDefaultAttributes(d *schema.ResourceData, c *Client, attributes []Attribute) []Attribute {
+	configured := make(map[string]bool)
+	for _, attribute := range expandAttributesFromInterfaces(d.Get("attribute").(*schema.Set).List()) {
+		configured[attribute.Key] = true
+	}
+
+	res := make([]Attribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		if value, ok := c.DefaultAttributes[attribute.Key]; ok && value == attribute.Value && !configured[attribute.Key] {
+			continue
+		}
+		res = append(res, attribute)
+	}
+	return res
 }
 
 func expandAttributesFromInterfaces(drs []interface{}) []Attribute {
@@ -159,14 +543,138 @@ func flattenAttributes(attributes []Attribute) []map[string]interface{} {
 	return res
 }
 
-func expandDestinationReferences(drs []interface{}) ([]DestinationReference, error) {
-	res := make([]DestinationReference, 0, len(drs))
+// Resolves a destination reference, which may be either a destination's
+// ID or its name. Returns false if no destination has the name.
+func findDestinationID(c *Client, ref string) (int, bool, error) {
+	if identifierPattern.MatchString(ref) {
+		id, err := strconv.Atoi(ref)
+		return id, err == nil, err
+	}
+	destination, err := c.FindDestination(ref)
+	if err != nil || destination == nil {
+		return 0, false, err
+	}
+	return destination.ID, true, nil
+}
 
+// Returns the IDs of the destinations in a list of destination blocks.
+// Destinations which can no longer be found are left out.
+func destinationIDs(c *Client, drs []interface{}) (map[int]bool, error) {
+	ids := make(map[int]bool)
 	for _, dr := range drs {
 		val, _ := dr.(map[string]interface{})
+		ref, _ := val["destination"].(string)
+		if ref == "" {
+			continue
+		}
+		id, found, err := findDestinationID(c, ref)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			ids[id] = true
+		}
+	}
+	return ids, nil
+}
+
+// Checks that no save option key of the destination blocks is given
+// twice, whether by two option blocks or by a block and the options map.
+// Blocks whose options are unchanged from old are skipped, as the options
+// read back on import stay in the map until they change. The path names
+// the blocks in the error, such as "destination".
+func checkDestinationOptionKeys(path string, old, new []interface{}) error {
+	for i, dr := range new {
+		val, _ := dr.(map[string]interface{})
+		if i < len(old) {
+			prev, _ := old[i].(map[string]interface{})
+			if reflect.DeepEqual(destinationOptions(prev["option"], prev["options"]), destinationOptions(val["option"], val["options"])) {
+				continue
+			}
+		}
+		seen := make(map[string]bool)
+		if set, ok := val["option"].(*schema.Set); ok {
+			for _, option := range set.List() {
+				key, _ := option.(map[string]interface{})["key"].(string)
+				if key != "" && seen[key] {
+					return fmt.Errorf("%s.%d has more than one option block with the key %q", path, i, key)
+				}
+				seen[key] = true
+			}
+		}
+		options, _ := val["options"].(map[string]interface{})
+		for key := range options {
+			if seen[key] {
+				return fmt.Errorf("%s.%d gives the save option %q both as an option block and in options", path, i, key)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the save options of a destination block, whether given as
+// option blocks or in the options map.
+func destinationOptions(blocks, options interface{}) map[string]string {
+	res := make(map[string]string)
+	if set, ok := blocks.(*schema.Set); ok {
+		for _, option := range expandAttributesFromInterfaces(set.List()) {
+			res[option.Key] = option.Value
+		}
+	}
+	configured, _ := options.(map[string]interface{})
+	for key, value := range expandStringMap(configured) {
+		res[key] = value
+	}
+	return res
+}
 
-		destID, _ := strconv.Atoi(val["destination"].(string))
+// Ignores changes which only move save options between the option blocks
+// and the options map. Nothing tells the two apart when a destination is
+// imported, so its options are all read into the map.
+func suppressDestinationOptionsDiff(k, old, new string, d *schema.ResourceData) bool {
+	// The whole list is read, as reading the option blocks or the options
+	// map alone falls back to the state when the configuration omits them.
+	prefix := k[:strings.Index(k, ".option")]
+	dot := strings.LastIndex(prefix, ".")
+	i, err := strconv.Atoi(prefix[dot+1:])
+	if err != nil {
+		return false
+	}
+	o, n := d.GetChange(prefix[:dot])
+	oldDestinations, _ := o.([]interface{})
+	newDestinations, _ := n.([]interface{})
+	if i >= len(oldDestinations) || i >= len(newDestinations) {
+		return false
+	}
+	oldDestination, _ := oldDestinations[i].(map[string]interface{})
+	newDestination, _ := newDestinations[i].(map[string]interface{})
+	return reflect.DeepEqual(
+		destinationOptions(oldDestination["option"], oldDestination["options"]),
+		destinationOptions(newDestination["option"], newDestination["options"]),
+	)
+}
+
+// Expands the destination blocks under key, such as "destination".
+func expandDestinationReferences(c *Client, d *schema.ResourceData, key string) ([]DestinationReference, error) {
+	drs := d.Get(key).([]interface{})
+	res := make([]DestinationReference, 0, len(drs))
+
+	for i, dr := range drs {
+		val, _ := dr.(map[string]interface{})
+
+		ref := val["destination"].(string)
+		destID, ok, err := findDestinationID(c, ref)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("Destination %s not found", ref)
+		}
 		options := expandAttributesFromInterfaces(val["option"].(*schema.Set).List())
+		for key, value := range expandStringMap(val["options"].(map[string]interface{})) {
+			options = append(options, Attribute{Key: key, Value: value})
+		}
+		sort.SliceStable(options, func(i, j int) bool { return options[i].Key < options[j].Key })
 
 		parsed := DestinationReference{
 			DestinationID: destID,
@@ -177,8 +685,7 @@ func expandDestinationReferences(drs []interface{}) ([]DestinationReference, err
 			if path, ok := folder["path"].(string); ok {
 				parsed.Type = "folder"
 				parsed.Folder = path
-				enabled := folder["partitioning_enabled"].(bool)
-				parsed.FolderPartitioningEnabled = &enabled
+				parsed.FolderPartitioningEnabled = optionalBool(d, fmt.Sprintf("%s.%d.folder.0.partitioning_enabled", key, i))
 				mode := folder["save_mode"].(string)
 				parsed.Mode = mode
 			} else {
@@ -216,14 +723,52 @@ func expandDestinationReferences(drs []interface{}) ([]DestinationReference, err
 	return res, nil
 }
 
-func flattenDestinationReferences(destinations []DestinationReference) ([]map[string]interface{}, error) {
+// Options are read back into the option blocks when the configured
+// destination at the same position has an option block with that key,
+// and into the options map otherwise. A destination configured by name
+// keeps its name while it still resolves to the same destination.
+func flattenDestinationReferences(c *Client, destinations []DestinationReference, configured []interface{}) ([]map[string]interface{}, error) {
 	res := make([]map[string]interface{}, 0, len(destinations))
 
-	for _, destination := range destinations {
+	for i, destination := range destinations {
 		single := make(map[string]interface{})
 		single["destination"] = strconv.Itoa(destination.DestinationID)
+		if i < len(configured) {
+			if val, ok := configured[i].(map[string]interface{}); ok {
+				if ref, _ := val["destination"].(string); ref != "" && !identifierPattern.MatchString(ref) {
+					id, found, err := findDestinationID(c, ref)
+					if err != nil {
+						return nil, err
+					}
+					if found && id == destination.DestinationID {
+						single["destination"] = ref
+					}
+				}
+			}
+		}
 		if destination.Options != nil {
-			single["option"] = flattenAttributes(destination.Options)
+			blockKeys := make(map[string]bool)
+			if i < len(configured) {
+				if val, ok := configured[i].(map[string]interface{}); ok {
+					if set, ok := val["option"].(*schema.Set); ok {
+						for _, option := range expandAttributesFromInterfaces(set.List()) {
+							blockKeys[option.Key] = true
+						}
+					}
+				}
+			}
+
+			blocks := make([]Attribute, 0, len(destination.Options))
+			options := make(map[string]string)
+			for _, option := range destination.Options {
+				if blockKeys[option.Key] {
+					blocks = append(blocks, option)
+				} else {
+					options[option.Key] = option.Value
+				}
+			}
+			single["option"] = flattenAttributes(blocks)
+			single["options"] = options
 		}
 
 		if destination.Type == "folder" {
@@ -262,3 +807,92 @@ func flattenDestinationReferences(destinations []DestinationReference) ([]map[st
 
 	return res, nil
 }
+
+func entityRestrictionLabelsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Description: "Restrict the feature to the entities carrying all of these labels. " +
+			"The labels are resolved to entity Id's when the resource is created or updated. " +
+			"Refreshing resolves them again, so labelling another entity shows as a change in the next plan.",
+		ConflictsWith: []string{"entity_restrictions"},
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+	}
+}
+
+// Returns the sorted IDs of the entities which carry all of the labels.
+func resolveEntityRestrictionLabels(c *Client, labels []string) ([]int, error) {
+	entities, err := c.ListEntitiesByLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]int, 0, len(entities))
+	for _, entity := range entities {
+		res = append(res, entity.ID)
+	}
+	sort.Ints(res)
+	return res, nil
+}
+
+// Returns the entity restrictions to send, either as listed or resolved
+// from the configured labels.
+func expandEntityRestrictions(d *schema.ResourceData, c *Client) (*[]int, error) {
+	if labels := expandStringList(d.Get("entity_restriction_labels").([]interface{})); len(labels) > 0 {
+		resolved, err := resolveEntityRestrictionLabels(c, labels)
+		if err != nil {
+			return nil, err
+		}
+		// An empty restriction would leave the feature unrestricted.
+		if len(resolved) == 0 {
+			return nil, fmt.Errorf("No entities carry all of the labels %s", strings.Join(labels, ", "))
+		}
+		return &resolved, nil
+	}
+
+	entityRestrictions := d.Get("entity_restrictions").([]interface{})
+	if len(entityRestrictions) > 0 {
+		listVal := expandIdentifierList(entityRestrictions)
+		return &listVal, nil
+	}
+	return nil, nil
+}
+
+// Stores the entity restrictions read from the server. Configured labels
+// are kept while they still resolve to the same entities, otherwise the
+// IDs are stored so that the change shows in the plan.
+func setEntityRestrictions(d *schema.ResourceData, c *Client, entityRestrictions *[]int) error {
+	if labels := expandStringList(d.Get("entity_restriction_labels").([]interface{})); len(labels) > 0 && entityRestrictions != nil {
+		resolved, err := resolveEntityRestrictionLabels(c, labels)
+		if err != nil {
+			return err
+		}
+		current := append([]int(nil), *entityRestrictions...)
+		sort.Ints(current)
+		if len(resolved) > 0 && sameIdentifiers(resolved, current) {
+			return d.Set("entity_restrictions", nil)
+		}
+	}
+
+	if err := d.Set("entity_restriction_labels", nil); err != nil {
+		return err
+	}
+	if entityRestrictions != nil {
+		return d.Set("entity_restrictions", identifierList(*entityRestrictions))
+	}
+	return d.Set("entity_restrictions", nil)
+}
+
+func sameIdentifiers(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
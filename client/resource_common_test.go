@@ -0,0 +1,844 @@
+package anaml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestExpandDestinationReferencesPartitioning(t *testing.T) {
+	yes, no := true, false
+	cases := []struct {
+		name   string
+		folder map[string]interface{}
+		want   *bool
+	}{
+		{"unset", map[string]interface{}{"path": "/out", "save_mode": "overwrite"}, nil},
+		{"disabled", map[string]interface{}{"path": "/out", "save_mode": "overwrite", "partitioning_enabled": false}, &no},
+		{"enabled", map[string]interface{}{"path": "/out", "save_mode": "overwrite", "partitioning_enabled": true}, &yes},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceFeatureStore().Schema, map[string]interface{}{
+				"destination": []interface{}{
+					map[string]interface{}{
+						"destination": "7",
+						"folder":      []interface{}{tt.folder},
+					},
+				},
+			})
+
+			refs, err := expandDestinationReferences(nil, d, "destination")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(refs) != 1 || refs[0].Type != "folder" || refs[0].DestinationID != 7 {
+				t.Fatalf("refs = %+v", refs)
+			}
+			got := refs[0].FolderPartitioningEnabled
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("partitioning enabled = %v, want %v", formatBool(got), formatBool(tt.want))
+			}
+		})
+	}
+}
+
+func TestCheckDestinationOptionKeys(t *testing.T) {
+	options := func(pairs ...string) *schema.Set {
+		set := schema.NewSet(schema.HashResource(attributeSchema()), nil)
+		for i := 0; i < len(pairs); i += 2 {
+			set.Add(map[string]interface{}{"key": pairs[i], "value": pairs[i+1]})
+		}
+		return set
+	}
+
+	cases := []struct {
+		name    string
+		option  *schema.Set
+		options map[string]interface{}
+		old     map[string]interface{}
+		wantErr bool
+	}{
+		{"none", options(), map[string]interface{}{}, nil, false},
+		{"distinct keys", options("compression", "gzip"), map[string]interface{}{"maxRecordsPerFile": "100"}, nil, false},
+		{"block and map", options("compression", "gzip"), map[string]interface{}{"compression": "snappy"}, nil, true},
+		{"two blocks", options("compression", "gzip", "compression", "snappy"), map[string]interface{}{}, nil, true},
+		{"block and map after import", options("compression", "gzip"), map[string]interface{}{"compression": "gzip"}, map[string]interface{}{"compression": "gzip"}, false},
+		{"block and map changed after import", options("compression", "gzip"), map[string]interface{}{"compression": "snappy"}, map[string]interface{}{"compression": "gzip"}, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var old []interface{}
+			if tt.old != nil {
+				old = []interface{}{
+					map[string]interface{}{"destination": "1", "option": options(), "options": tt.old},
+				}
+			}
+			drs := []interface{}{
+				map[string]interface{}{"destination": "1", "option": tt.option, "options": tt.options},
+			}
+			err := checkDestinationOptionKeys("destination", old, drs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuppressDestinationOptionsDiff(t *testing.T) {
+	block := func(key, value string) []interface{} {
+		return []interface{}{map[string]interface{}{"key": key, "value": value}}
+	}
+	cases := []struct {
+		name      string
+		options   map[string]interface{}
+		wantEmpty bool
+	}{
+		{"moved to a block", map[string]interface{}{"option": block("compression", "gzip")}, true},
+		{"kept in the map", map[string]interface{}{"options": map[string]interface{}{"compression": "gzip"}}, true},
+		{"changed in a block", map[string]interface{}{"option": block("compression", "snappy")}, false},
+		{"changed in the map", map[string]interface{}{"options": map[string]interface{}{"compression": "snappy"}}, false},
+		{"removed", map[string]interface{}{}, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config := func(options map[string]interface{}) map[string]interface{} {
+				destination := map[string]interface{}{
+					"destination": "11",
+					"folder":      []interface{}{map[string]interface{}{"path": "/out", "save_mode": "overwrite"}},
+				}
+				for k, v := range options {
+					destination[k] = v
+				}
+				return map[string]interface{}{
+					"name":        "daily",
+					"feature_set": "1",
+					"destination": []interface{}{destination},
+				}
+			}
+			// An imported destination has all of its options in the map.
+			d := schema.TestResourceDataRaw(t, ResourceFeatureStore().Schema, config(map[string]interface{}{
+				"options": map[string]interface{}{"compression": "gzip"},
+			}))
+			d.SetId("3")
+
+			diff, err := ResourceFeatureStore().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config(tt.options)), &Client{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.Empty() != tt.wantEmpty {
+				t.Errorf("diff = %v, want empty %v", diff, tt.wantEmpty)
+			}
+		})
+	}
+}
+
+func TestExpandLabels(t *testing.T) {
+	cases := []struct {
+		name            string
+		caseInsensitive bool
+		defaults        []string
+		configured      []string
+		want            []string
+	}{
+		{"as written", false, nil, []string{"PII", "Finance"}, []string{"Finance", "PII"}},
+		{"with defaults", false, []string{"Team"}, []string{"PII"}, []string{"PII", "Team"}},
+		{"default differs in case", false, []string{"team"}, []string{"Team"}, []string{"Team", "team"}},
+		{"case insensitive", true, nil, []string{"PII", "Finance"}, []string{"finance", "pii"}},
+		{"case insensitive, default differs in case", true, []string{"team"}, []string{"TEAM", "PII"}, []string{"pii", "team"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{CaseInsensitiveLabels: tt.caseInsensitive, DefaultLabels: tt.defaults}
+			d := schema.TestResourceDataRaw(t, ResourceEntity().Schema, map[string]interface{}{
+				"labels": toInterfaceList(tt.configured),
+			})
+
+			got := expandLabels(d, c)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenLabels(t *testing.T) {
+	cases := []struct {
+		name            string
+		caseInsensitive bool
+		defaults        []string
+		configured      []string
+		read            []string
+		want            []string
+	}{
+		{"as read", false, nil, []string{"PII"}, []string{"PII", "Finance"}, []string{"Finance", "PII"}},
+		{"drops defaults", false, []string{"Team"}, []string{"PII"}, []string{"PII", "Team"}, []string{"PII"}},
+		{"keeps configured default", false, []string{"Team"}, []string{"Team"}, []string{"Team"}, []string{"Team"}},
+		{"case differs", false, []string{"team"}, []string{"PII"}, []string{"pii", "Team"}, []string{"Team", "pii"}},
+		{"case insensitive, as configured", true, nil, []string{"PII", "Finance"}, []string{"pii", "finance"}, []string{"Finance", "PII"}},
+		{"case insensitive, drops defaults", true, []string{"TEAM"}, []string{"PII"}, []string{"pii", "team"}, []string{"PII"}},
+		{"case insensitive, keeps configured default", true, []string{"team"}, []string{"Team"}, []string{"team"}, []string{"Team"}},
+		{"case insensitive, not configured", true, nil, []string{"PII"}, []string{"pii", "Daily"}, []string{"Daily", "PII"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{CaseInsensitiveLabels: tt.caseInsensitive, DefaultLabels: tt.defaults}
+			d := schema.TestResourceDataRaw(t, ResourceEntity().Schema, map[string]interface{}{
+				"labels": toInterfaceList(tt.configured),
+			})
+
+			got := flattenLabels(d, c, tt.read)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flattenLabels(%v) = %v, want %v", tt.read, got, tt.want)
+			}
+		})
+	}
+}
+
+func toInterfaceList(values []string) []interface{} {
+	res := make([]interface{}, len(values))
+	for i, value := range values {
+		res[i] = value
+	}
+	return res
+}
+
+func TestFindPrincipal(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		switch {
+		case r.URL.Path == "/user" && name == "alice":
+			w.Write([]byte(`[{"id": 5, "name": "alice"}]`))
+		case r.URL.Path == "/user" && name == "sam":
+			w.Write([]byte(`[{"id": 8, "name": "sam"}, {"id": 9, "name": "sam"}]`))
+		case r.URL.Path == "/user-group" && name == "admins":
+			w.Write([]byte(`[{"id": 7, "name": "admins"}]`))
+		case r.URL.Path == "/user-group" && name == "ops":
+			w.Write([]byte(`[{"id": 10, "name": "ops"}, {"id": 11, "name": "ops"}]`))
+		case r.URL.Path == "/user" || r.URL.Path == "/user-group":
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cases := []struct {
+		ref       string
+		wantID    int
+		wantFound bool
+		wantErr   string
+	}{
+		{ref: "12", wantID: 12, wantFound: true},
+		{ref: "alice", wantID: 5, wantFound: true},
+		{ref: "user:alice", wantID: 5, wantFound: true},
+		{ref: "group:admins", wantID: 7, wantFound: true},
+		{ref: "bob"},
+		{ref: "user:bob"},
+		{ref: "group:nobody"},
+		{ref: "user:sam", wantErr: `More than one user is named "sam"`},
+		{ref: "group:ops", wantErr: `More than one user group is named "ops"`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.ref, func(t *testing.T) {
+			id, found, err := findPrincipal(c, tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found != tt.wantFound || id != tt.wantID {
+				t.Errorf("findPrincipal(%q) = %d, %v, want %d, %v", tt.ref, id, found, tt.wantID, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestResolvePrincipal(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("name") {
+		case "alice":
+			w.Write([]byte(`[{"id": 5, "name": "alice"}]`))
+		case "admins":
+			w.Write([]byte(`[{"id": 7, "name": "admins"}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	})
+
+	cases := []struct {
+		principal string
+		want      int
+		wantErr   string
+	}{
+		{"", 0, ""},
+		{"12", 12, ""},
+		{"user:alice", 5, ""},
+		{"group:admins", 7, ""},
+		{"group:nobody", 0, "No user or group group:nobody found for principal"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.principal, func(t *testing.T) {
+			config := map[string]interface{}{
+				"name":    "daily",
+				"enabled": true,
+				"include": []interface{}{map[string]interface{}{"tables": []interface{}{"1"}}},
+			}
+			if tt.principal != "" {
+				config["principal"] = tt.principal
+			}
+			d := schema.TestResourceDataRaw(t, ResourceTableMonitoring().Schema, config)
+
+			got, err := resolvePrincipal(c, d, "principal")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == 0 {
+				if got != nil {
+					t.Errorf("principal = %d, want none", *got)
+				}
+				return
+			}
+			if got == nil || *got != tt.want {
+				t.Errorf("principal = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindOwner(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/user" && r.URL.Query().Get("name") == "alice":
+			w.Write([]byte(`[{"id": 5, "name": "alice"}]`))
+		case r.URL.Path == "/user/5":
+			w.Write([]byte(`{"id": 5, "name": "alice"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cases := []struct {
+		owner   string
+		wantID  int
+		wantErr string
+	}{
+		{owner: "alice", wantID: 5},
+		{owner: "user:alice", wantID: 5},
+		{owner: "5", wantID: 5},
+		{owner: "user:5", wantID: 5},
+		{owner: "bob"},
+		{owner: "group:admins", wantErr: "only a user can be an owner"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.owner, func(t *testing.T) {
+			user, err := findOwner(c, tt.owner)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantID == 0 {
+				if user != nil {
+					t.Errorf("user = %+v, want none", user)
+				}
+				return
+			}
+			if user == nil || user.ID != tt.wantID {
+				t.Errorf("user = %+v, want ID %d", user, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestValidateOwner(t *testing.T) {
+	for _, owner := range []string{"alice", "user:alice", "5"} {
+		if _, errs := validateOwner()(owner, "owner"); len(errs) > 0 {
+			t.Errorf("%s: unexpected errors %v", owner, errs)
+		}
+	}
+	for _, owner := range []string{"group:admins", " "} {
+		if _, errs := validateOwner()(owner, "owner"); len(errs) == 0 {
+			t.Errorf("%s: expected an error", owner)
+		}
+	}
+}
+
+func TestSuppressWhitespaceDiff(t *testing.T) {
+	cases := []struct {
+		name     string
+		old, new string
+		suppress bool
+	}{
+		{"same", "Customers", "Customers", true},
+		{"trailing newline", "Customers", "Customers\n", true},
+		{"leading and trailing spaces", "Customers", "  Customers\t", true},
+		{"trimmed by the server", "Customers\n", "Customers", true},
+		{"blank", "", "  \n", true},
+		{"changed", "Customers", "Clients", false},
+		{"inner whitespace", "All customers", "All  customers", false},
+		{"added", "", "Customers", false},
+		{"removed", "Customers", "", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suppressWhitespaceDiff("description", tt.old, tt.new, nil); got != tt.suppress {
+				t.Errorf("suppressWhitespaceDiff(%q, %q) = %v, want %v", tt.old, tt.new, got, tt.suppress)
+			}
+		})
+	}
+
+	// A heredoc description ends in a newline, which the server trims.
+	d := schema.TestResourceDataRaw(t, ResourceEntity().Schema, map[string]interface{}{
+		"name":           "customer",
+		"default_column": "customer_id",
+		"description":    "Customers",
+	})
+	d.SetId("1")
+	for description, wantDiff := range map[string]bool{"Customers\n": false, "Clients\n": true} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":           "customer",
+			"default_column": "customer_id",
+			"description":    description,
+		})
+		diff, err := ResourceEntity().Diff(context.Background(), d.State(), config, &Client{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotDiff := !diff.Empty(); gotDiff != wantDiff {
+			t.Errorf("description %q gave diff %v, want a diff %v", description, diff, wantDiff)
+		}
+	}
+}
+
+func TestDeletionProtection(t *testing.T) {
+	resources := []struct {
+		kind     string
+		resource func() *schema.Resource
+	}{
+		{"Cluster", ResourceCluster},
+		{"Destination", ResourceDestination},
+		{"Entity", ResourceEntity},
+		{"Feature", ResourceFeature},
+		{"Feature set", ResourceFeatureSet},
+		{"Feature store", ResourceFeatureStore},
+		{"Feature template", ResourceFeatureTemplate},
+		{"Source", ResourceSource},
+		{"Table", ResourceTable},
+	}
+
+	for _, tt := range resources {
+		for _, protected := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s protected %v", tt.kind, protected), func(t *testing.T) {
+				deletes := 0
+				c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == "DELETE" {
+						deletes++
+					}
+				})
+
+				d := schema.TestResourceDataRaw(t, tt.resource().Schema, map[string]interface{}{
+					"deletion_protection": protected,
+				})
+				d.SetId("4")
+
+				diags := tt.resource().DeleteContext(context.Background(), d, c)
+				if protected {
+					want := tt.kind + " 4 has deletion protection enabled"
+					if len(diags) != 1 || !strings.HasPrefix(diags[0].Summary, want) {
+						t.Errorf("diags = %v, want %q", diags, want)
+					}
+					if deletes != 0 {
+						t.Errorf("sent %d deletes, want none", deletes)
+					}
+					return
+				}
+				if diags.HasError() {
+					t.Errorf("diags = %v, want none", diags)
+				}
+				if deletes != 1 {
+					t.Errorf("sent %d deletes, want 1", deletes)
+				}
+			})
+		}
+	}
+}
+
+func TestReadAfterCreate(t *testing.T) {
+	cases := []struct {
+		name      string
+		misses    int
+		readErr   error
+		wantErr   string
+		wantReads int
+	}{
+		{"readable straight away", 0, nil, "", 1},
+		{"readable after a miss", 1, nil, "", 2},
+		{"readable after two misses", 2, nil, "", 3},
+		{"read fails", 0, errors.New("boom"), "boom", 1},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			reads := 0
+			read := func(d *schema.ResourceData, m interface{}) error {
+				reads++
+				if tt.readErr != nil {
+					return tt.readErr
+				}
+				if reads <= tt.misses {
+					d.SetId("")
+				}
+				return nil
+			}
+
+			d := schema.TestResourceDataRaw(t, ResourceEntity().Schema, map[string]interface{}{})
+			err := readAfterCreate(d, nil, "7", read)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("err = %v, want none", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if reads != tt.wantReads {
+				t.Errorf("read %d times, want %d", reads, tt.wantReads)
+			}
+			if tt.readErr == nil && d.Id() != "7" {
+				t.Errorf("id = %q, want 7", d.Id())
+			}
+		})
+	}
+}
+
+func TestPoll(t *testing.T) {
+	cases := []struct {
+		name       string
+		doneAfter  int
+		checkErr   error
+		timeout    time.Duration
+		wantErr    error
+		wantChecks int
+	}{
+		{"done straight away", 1, nil, time.Second, nil, 1},
+		{"done after retries", 3, nil, time.Second, nil, 3},
+		{"check fails", 1, errors.New("boom"), time.Second, errors.New("boom"), 1},
+		{"times out", 100, nil, 100 * time.Millisecond, errPollTimeout, 4},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := 0
+			err := poll(10*time.Millisecond, tt.timeout, func() (bool, error) {
+				checks++
+				return checks >= tt.doneAfter, tt.checkErr
+			})
+			if fmt.Sprint(err) != fmt.Sprint(tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if checks != tt.wantChecks {
+				t.Errorf("checked %d times, want %d", checks, tt.wantChecks)
+			}
+		})
+	}
+}
+
+// Bulk tagging isn't a server object, so it has nothing to find.
+func TestReadNotFound(t *testing.T) {
+	resources := []struct {
+		name     string
+		resource func() *schema.Resource
+		state    map[string]interface{}
+	}{
+		{"access token", ResourceAccessToken, map[string]interface{}{"owner": "alice", "owner_id": "2"}},
+		{"attribute restriction", ResourceAttributeRestriction, nil},
+		{"branch protection", ResourceBranchProtection, nil},
+		{"caching", ResourceTableCaching, nil},
+		{"cluster", ResourceCluster, nil},
+		{"destination", ResourceDestination, nil},
+		{"entity", ResourceEntity, nil},
+		{"entity mapping", ResourceEntityMapping, nil},
+		{"entity population", ResourceEntityPopulation, nil},
+		{"event store", ResourceEventStore, nil},
+		{"feature", ResourceFeature, nil},
+		{"feature set", ResourceFeatureSet, nil},
+		{"feature store", ResourceFeatureStore, nil},
+		{"feature template", ResourceFeatureTemplate, nil},
+		{"label restriction", ResourceLabelRestriction, nil},
+		{"monitoring", ResourceTableMonitoring, nil},
+		{"source", ResourceSource, nil},
+		{"spark property bundle", ResourceSparkPropertyBundle, nil},
+		{"table", ResourceTable, nil},
+		{"user", ResourceUser, nil},
+		{"user group", ResourceUserGroup, nil},
+		{"view materialisation job", ResourceViewMaterialisationJob, nil},
+		{"webhook", ResourceWebhook, nil},
+	}
+
+	for _, tt := range resources {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "GET" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(http.StatusNotFound)
+			})
+
+			d := tt.resource().Data(nil)
+			d.SetId("4")
+			for k, v := range tt.state {
+				if err := d.Set(k, v); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if diags := tt.resource().ReadContext(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("diags = %v, want none", diags)
+			}
+			if d.Id() != "" {
+				t.Errorf("ID = %q, want it cleared", d.Id())
+			}
+		})
+	}
+}
+
+func TestAccessTokenReadKeepsOwnerID(t *testing.T) {
+	cases := []struct {
+		name      string
+		ownerID   string
+		wantGet   string
+		wantError bool
+	}{
+		// alice has been renamed, so only the ID kept in state finds her.
+		{"owner ID in state", "2", "/user/2/access-token/4", false},
+		{"no owner ID in state", "", "", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var gets []string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				gets = append(gets, r.URL.Path)
+				switch r.URL.Path {
+				case "/user":
+					w.Write([]byte(`[]`))
+				case "/user/2/access-token/4":
+					w.Write([]byte(`{"id": "4", "owner": 2, "description": "ci"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			d := ResourceAccessToken().Data(nil)
+			d.SetId("4")
+			d.Set("owner", "alice")
+			d.Set("owner_id", tt.ownerID)
+
+			diags := ResourceAccessToken().ReadContext(context.Background(), d, c)
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("diags = %v, want error %v", diags, tt.wantError)
+			}
+			if d.Id() != "4" {
+				t.Errorf("ID = %q, want the token kept in state", d.Id())
+			}
+			if tt.wantGet != "" && gets[0] != tt.wantGet {
+				t.Errorf("requests = %v, want %s", gets, tt.wantGet)
+			}
+			if !tt.wantError && d.Get("owner_id") != "2" {
+				t.Errorf("owner_id = %v, want 2", d.Get("owner_id"))
+			}
+		})
+	}
+}
+
+func TestImportByIDOrName(t *testing.T) {
+	resources := []struct {
+		name     string
+		resource func() *schema.Resource
+	}{
+		{"branch protection", ResourceBranchProtection},
+		{"cluster", ResourceCluster},
+		{"destination", ResourceDestination},
+		{"entity", ResourceEntity},
+		{"entity population", ResourceEntityPopulation},
+		{"event store", ResourceEventStore},
+		{"feature", ResourceFeature},
+		{"feature set", ResourceFeatureSet},
+		{"feature store", ResourceFeatureStore},
+		{"feature template", ResourceFeatureTemplate},
+		{"source", ResourceSource},
+		{"spark property bundle", ResourceSparkPropertyBundle},
+		{"table", ResourceTable},
+		{"user", ResourceUser},
+		{"user group", ResourceUserGroup},
+		{"view materialisation job", ResourceViewMaterialisationJob},
+		{"webhook", ResourceWebhook},
+	}
+	cases := []struct {
+		name    string
+		id      string
+		server  string
+		wantID  string
+		wantErr string
+	}{
+		{"ID", "4", `[]`, "4", ""},
+		{"name", "daily", `[{"id": 4, "name": "daily", "protectionPattern": "daily"}]`, "4", ""},
+		{"unknown name", "daily", `[]`, "", "either its numeric ID or its name"},
+	}
+
+	for _, r := range resources {
+		for _, tt := range cases {
+			t.Run(r.name+"/"+tt.name, func(t *testing.T) {
+				c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte(tt.server))
+				})
+
+				d := r.resource().Data(nil)
+				d.SetId(tt.id)
+				imported, err := r.resource().Importer.State(d, c)
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("err = %v, want %q", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(imported) != 1 || imported[0].Id() != tt.wantID {
+					t.Errorf("imported ID = %q, want %q", imported[0].Id(), tt.wantID)
+				}
+			})
+		}
+	}
+}
+
+func TestImportAccessToken(t *testing.T) {
+	cases := []struct {
+		id          string
+		wantID      string
+		wantOwner   string
+		wantOwnerID string
+		wantErr     bool
+	}{
+		{"alice/abc", "abc", "alice", "2", false},
+		{"2/abc", "abc", "2", "2", false},
+		{"bob/abc", "", "", "", true},
+		{"abc", "", "", "", true},
+		{"alice/", "", "", "", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.id, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/user/2" || r.URL.Query().Get("name") == "alice":
+					w.Write([]byte(`{"id": 2, "name": "alice"}`))
+				default:
+					w.Write([]byte(`[]`))
+				}
+			})
+
+			d := ResourceAccessToken().Data(nil)
+			d.SetId(tt.id)
+			imported, err := ResourceAccessToken().Importer.State(d, c)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("imported %v, want an error", imported)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Id() != tt.wantID || d.Get("owner") != tt.wantOwner || d.Get("owner_id") != tt.wantOwnerID {
+				t.Errorf("imported %q owned by %v (%v), want %q owned by %s (%s)", d.Id(), d.Get("owner"), d.Get("owner_id"), tt.wantID, tt.wantOwner, tt.wantOwnerID)
+			}
+		})
+	}
+}
+
+func TestUpdateFailureKeepsState(t *testing.T) {
+	resources := []struct {
+		name     string
+		resource func() *schema.Resource
+		config   map[string]interface{}
+	}{
+		{"entity", ResourceEntity, map[string]interface{}{"name": "customer", "default_column": "customer_id"}},
+		{"feature set", ResourceFeatureSet, map[string]interface{}{"name": "daily", "entity": "1", "features": []interface{}{"2"}}},
+		{"user group", ResourceUserGroup, map[string]interface{}{"name": "engineering"}},
+		{"webhook", ResourceWebhook, map[string]interface{}{"name": "alerts", "url": "https://example.com/hook"}},
+	}
+
+	for _, tt := range resources {
+		t.Run(tt.name, func(t *testing.T) {
+			puts := 0
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "PUT" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				puts++
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+
+			config := func(description string) map[string]interface{} {
+				res := map[string]interface{}{"description": description}
+				for k, v := range tt.config {
+					res[k] = v
+				}
+				return res
+			}
+			d := schema.TestResourceDataRaw(t, tt.resource().Schema, config("before"))
+			d.SetId("4")
+
+			resource := tt.resource()
+			diff, err := resource.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config("after")), c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state, diags := resource.Apply(context.Background(), d.State(), diff, c)
+			if !diags.HasError() {
+				t.Fatal("diags has no error, want the update's")
+			}
+			if puts != 1 {
+				t.Errorf("sent %d updates, want 1", puts)
+			}
+			if got := state.Attributes["description"]; got != "before" {
+				t.Errorf("description in state = %q, want the old value kept", got)
+			}
+		})
+	}
+}
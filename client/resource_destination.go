@@ -1,6 +1,7 @@
 package anaml
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -9,6 +10,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var destinationTypes = []string{"s3", "s3a", "jdbc", "hive", "big_query", "gcs", "local", "hdfs", "online", "kafka", "snowflake", "bigtable", "dynamodb", "cassandra", "redis", "postgres"}
+
 const destinationDescription = `# Destinations
 
 A Destination is the physical configuration for the location of feature run
@@ -25,16 +28,17 @@ Multiple different types of destinations are supported:
 
 func ResourceDestination() *schema.Resource {
 	return &schema.Resource{
-		Description: destinationDescription,
-		Create:      resourceDestinationCreate,
-		Read:        resourceDestinationRead,
-		Update:      resourceDestinationUpdate,
-		Delete:      resourceDestinationDelete,
+		Description:   destinationDescription,
+		CreateContext: withWarnings(resourceDestinationCreate),
+		ReadContext:   withDiagnostics(resourceDestinationRead),
+		UpdateContext: withWarnings(resourceDestinationUpdate),
+		DeleteContext: withDiagnostics(resourceDestinationDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("destination", (*Client).FindDestination),
 		},
+		CustomizeDiff: customizeDestinationDiff,
 
-		Schema: map[string]*schema.Schema{
+		Schema: auditSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Description:  "The name of the destination.",
@@ -42,21 +46,22 @@ func ResourceDestination() *schema.Resource {
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"s3": {
 				Type:         schema.TypeList,
 				Optional:     true,
 				MaxItems:     1,
-				Elem:         s3SourceDestinationSchema(),
-				ExactlyOneOf: []string{"s3", "s3a", "jdbc", "hive", "big_query", "gcs", "local", "hdfs", "online", "kafka", "snowflake", "bigtable"},
+				Elem:         fileDestinationSchema(s3SourceDestinationSchema()),
+				ExactlyOneOf: destinationTypes,
 			},
 			"s3a": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     s3aSourceDestinationSchema(),
+				Elem:     fileDestinationSchema(s3aSourceDestinationSchema()),
 			},
 			"jdbc": {
 				Type:     schema.TypeList,
@@ -80,19 +85,19 @@ func ResourceDestination() *schema.Resource {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     gcsSourceDestinationSchema(),
+				Elem:     fileDestinationSchema(gcsSourceDestinationSchema()),
 			},
 			"local": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     localSourceDestinationSchema(),
+				Elem:     fileDestinationSchema(localSourceDestinationSchema()),
 			},
 			"hdfs": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     hdfsSourceDestinationSchema(),
+				Elem:     fileDestinationSchema(hdfsSourceDestinationSchema()),
 			},
 			"online": {
 				Type:     schema.TypeList,
@@ -118,6 +123,30 @@ func ResourceDestination() *schema.Resource {
 				MaxItems: 1,
 				Elem:     bigtableDestinationSchema(),
 			},
+			"dynamodb": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     dynamoDBDestinationSchema(),
+			},
+			"cassandra": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     cassandraDestinationSchema(),
+			},
+			"redis": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     redisDestinationSchema(),
+			},
+			"postgres": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     postgresDestinationSchema(),
+			},
 			"labels": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -130,6 +159,119 @@ func ResourceDestination() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"access_rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Access rules to attach to the object",
+				Elem:        accessRuleSchema(),
+			},
+			"deletion_protection": deletionProtectionSchema(),
+		}),
+	}
+}
+
+// Adds the write options which are only applicable to file based
+// destinations to a schema shared with sources.
+func fileDestinationSchema(resource *schema.Resource) *schema.Resource {
+	resource.Schema["orc"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Write options used when the file format is orc.",
+		Elem:        orcWriteOptionsSchema(),
+	}
+	resource.Schema["parquet"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Write options used when the file format is parquet.",
+		Elem:        parquetWriteOptionsSchema(),
+	}
+	return resource
+}
+
+// PostgreSQL destinations are JDBC destinations which can also upsert.
+func postgresDestinationSchema() *schema.Resource {
+	resource := jdbcSourceDestinationSchema()
+	resource.Schema["write_mode"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "append",
+		Description:  "How rows are written, either append or upsert.",
+		ValidateFunc: validation.StringInSlice([]string{"append", "upsert"}, false),
+	}
+	resource.Schema["conflict_columns"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "The columns identifying a row to update when upserting.",
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+	}
+	return resource
+}
+
+func customizeDestinationDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("postgres.0.write_mode").(string) != "upsert" || !d.NewValueKnown("postgres.0.conflict_columns") {
+		return nil
+	}
+	if len(d.Get("postgres.0.conflict_columns").([]interface{})) == 0 {
+		return errors.New("conflict_columns must be set when the postgres write_mode is upsert")
+	}
+	return nil
+}
+
+func orcWriteOptionsSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"bloom_filter_columns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Columns to create bloom filters for.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"stripe_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The size of each stripe in bytes.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"compression": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"none", "zlib", "snappy", "lzo", "lz4", "zstd",
+				}, false),
+			},
+		},
+	}
+}
+
+func parquetWriteOptionsSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"row_group_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The size of each row group in bytes.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The size of each page in bytes.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"enable_dictionary": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to use dictionary encoding.",
+			},
 		},
 	}
 }
@@ -154,6 +296,18 @@ func bigQueryDestinationSchema() *schema.Resource {
 				MaxItems: 1,
 				Elem:     bigQueryPersistentStagingAreaSchema(),
 			},
+			"write_disposition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "What BigQuery does when the table already has data, one of WRITE_APPEND, WRITE_TRUNCATE or WRITE_EMPTY. Unset uses the server's default",
+				ValidateFunc: validation.StringInSlice([]string{"WRITE_APPEND", "WRITE_TRUNCATE", "WRITE_EMPTY"}, false),
+			},
+			"create_disposition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Whether BigQuery creates the table when it doesn't exist, either CREATE_IF_NEEDED or CREATE_NEVER. Unset uses the server's default",
+				ValidateFunc: validation.StringInSlice([]string{"CREATE_IF_NEEDED", "CREATE_NEVER"}, false),
+			},
 		},
 	}
 }
@@ -262,6 +416,7 @@ func resourceDestinationRead(d *schema.ResourceData, m interface{}) error {
 		if err != nil {
 			return err
 		}
+		setCredentialVersion(d, "jdbc", jdbc)
 		if err := d.Set("jdbc", jdbc); err != nil {
 			return err
 		}
@@ -292,6 +447,7 @@ func resourceDestinationRead(d *schema.ResourceData, m interface{}) error {
 		if err != nil {
 			return err
 		}
+		setCredentialVersion(d, "online", online)
 		if err := d.Set("online", online); err != nil {
 			return err
 		}
@@ -307,6 +463,49 @@ func resourceDestinationRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
+	if destination.Type == "dynamodb" {
+		dynamoDB, err := parseDynamoDBDestination(destination)
+		if err != nil {
+			return err
+		}
+		setCredentialVersion(d, "dynamodb", dynamoDB)
+		if err := d.Set("dynamodb", dynamoDB); err != nil {
+			return err
+		}
+	}
+
+	if destination.Type == "cassandra" {
+		cassandra, err := parseCassandraDestination(destination)
+		if err != nil {
+			return err
+		}
+		setCredentialVersion(d, "cassandra", cassandra)
+		if err := d.Set("cassandra", cassandra); err != nil {
+			return err
+		}
+	}
+
+	if destination.Type == "redis" {
+		redis, err := parseRedisDestination(d, destination)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("redis", redis); err != nil {
+			return err
+		}
+	}
+
+	if destination.Type == "postgres" {
+		postgres, err := parsePostgresDestination(destination)
+		if err != nil {
+			return err
+		}
+		setCredentialVersion(d, "postgres", postgres)
+		if err := d.Set("postgres", postgres); err != nil {
+			return err
+		}
+	}
+
 	if destination.Type == "kafka" {
 		kafka, err := parseKafkaDestination(destination)
 		if err != nil {
@@ -322,56 +521,72 @@ func resourceDestinationRead(d *schema.ResourceData, m interface{}) error {
 		if err != nil {
 			return err
 		}
+		setCredentialVersion(d, "snowflake", snowflake)
+		setOAuthToken(d, "snowflake", snowflake)
 		if err := d.Set("snowflake", snowflake); err != nil {
 			return err
 		}
 	}
 
-	if err := d.Set("labels", destination.Labels); err != nil {
+	if err := setAuditMetadata(d, destination.AuditMetadata); err != nil {
+		return err
+	}
+	if err := d.Set("deletion_protection", d.Get("deletion_protection").(bool)); err != nil {
+		return err
+	}
+	if err := d.Set("labels", flattenLabels(d, c, destination.Labels)); err != nil {
+		return err
+	}
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, destination.Attributes))); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(destination.Attributes)); err != nil {
+	if err := d.Set("access_rule", flattenAccessRules(destination.AccessRules)); err != nil {
 		return err
 	}
 	return err
 }
 
-func resourceDestinationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDestinationCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	destination, err := composeDestination(d)
+	destination, err := composeDestination(d, c)
 	if destination == nil || err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateDestination(*destination)
+	e, warnings, err := c.CreateDestination(*destination)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceDestinationRead)
 }
 
-func resourceDestinationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDestinationUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	destinationID := d.Id()
-	destination, err := composeDestination(d)
+	destination, err := composeDestination(d, c)
 	if destination == nil || err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateDestination(destinationID, *destination)
+	warnings, err := c.UpdateDestination(destinationID, *destination)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceDestinationRead(d, m)
 }
 
 func resourceDestinationDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	destinationID := d.Id()
 
+	if err := checkDeletionProtection(d, "Destination"); err != nil {
+		return err
+	}
+
 	err := c.DeleteDestination(destinationID)
 	if err != nil {
 		return err
@@ -389,8 +604,22 @@ func parseS3Destination(destination *Destination) ([]map[string]interface{}, err
 	s3 := make(map[string]interface{})
 	s3["bucket"] = destination.Bucket
 	s3["path"] = destination.Path
+	// gcs shares this parser but has no endpoint or region
+	if destination.Type == "s3" {
+		s3["endpoint"] = destination.Endpoint
+		s3["region"] = destination.Region
+	}
 
-	fileFormat := parseFileFormat(destination.FileFormat)
+	if destination.FileFormat == nil {
+		return nil, fmt.Errorf("Destination %q of type %s has no file format", destination.Name, destination.Type)
+	}
+	fileFormat, err := parseFileFormat(destination.FileFormat)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range parseFileFormatWriteOptions(destination.FileFormat) {
+		fileFormat[k] = v
+	}
 	for k, v := range fileFormat {
 		s3[k] = v
 	}
@@ -412,7 +641,16 @@ func parseS3ADestination(destination *Destination) ([]map[string]interface{}, er
 	s3a["access_key"] = destination.AccessKey
 	s3a["secret_key"] = destination.SecretKey
 
-	fileFormat := parseFileFormat(destination.FileFormat)
+	if destination.FileFormat == nil {
+		return nil, fmt.Errorf("Destination %q of type %s has no file format", destination.Name, destination.Type)
+	}
+	fileFormat, err := parseFileFormat(destination.FileFormat)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range parseFileFormatWriteOptions(destination.FileFormat) {
+		fileFormat[k] = v
+	}
 	for k, v := range fileFormat {
 		s3a[k] = v
 	}
@@ -431,7 +669,16 @@ func parseLocalDestination(destination *Destination) ([]map[string]interface{},
 	local := make(map[string]interface{})
 	local["path"] = destination.Path
 
-	fileFormat := parseFileFormat(destination.FileFormat)
+	if destination.FileFormat == nil {
+		return nil, fmt.Errorf("Destination %q of type %s has no file format", destination.Name, destination.Type)
+	}
+	fileFormat, err := parseFileFormat(destination.FileFormat)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range parseFileFormatWriteOptions(destination.FileFormat) {
+		fileFormat[k] = v
+	}
 	for k, v := range fileFormat {
 		local[k] = v
 	}
@@ -441,6 +688,34 @@ func parseLocalDestination(destination *Destination) ([]map[string]interface{},
 	return locals, nil
 }
 
+// Parses the write options which are only present on destinations.
+func parseFileFormatWriteOptions(fileFormat *FileFormat) map[string]interface{} {
+	options := make(map[string]interface{})
+	if fileFormat.Type == "orc" {
+		if fileFormat.BloomFilterColumns != nil || fileFormat.StripeSize != nil || fileFormat.Compression != nil {
+			orc := make(map[string]interface{})
+			orc["bloom_filter_columns"] = fileFormat.BloomFilterColumns
+			orc["stripe_size"] = fileFormat.StripeSize
+			orc["compression"] = fileFormat.Compression
+			options["orc"] = []map[string]interface{}{orc}
+		} else {
+			options["orc"] = nil
+		}
+	}
+	if fileFormat.Type == "parquet" {
+		if fileFormat.RowGroupSize != nil || fileFormat.PageSize != nil || fileFormat.EnableDictionary != nil {
+			parquet := make(map[string]interface{})
+			parquet["row_group_size"] = fileFormat.RowGroupSize
+			parquet["page_size"] = fileFormat.PageSize
+			parquet["enable_dictionary"] = fileFormat.EnableDictionary
+			options["parquet"] = []map[string]interface{}{parquet}
+		} else {
+			options["parquet"] = nil
+		}
+	}
+	return options
+}
+
 func parseJDBCDestination(destination *Destination) ([]map[string]interface{}, error) {
 	if destination == nil {
 		return nil, errors.New("Destination is null")
@@ -450,6 +725,9 @@ func parseJDBCDestination(destination *Destination) ([]map[string]interface{}, e
 	jdbc["url"] = destination.URL
 	jdbc["schema"] = destination.Schema
 
+	if destination.CredentialsProvider == nil {
+		return nil, fmt.Errorf("Destination %q of type %s has no credentials provider", destination.Name, destination.Type)
+	}
 	credentialsProvider, err := parseLoginCredentialsProviderConfig(destination.CredentialsProvider)
 	if err != nil {
 		return nil, err
@@ -472,6 +750,8 @@ func parseBigQueryDestination(destination *Destination) ([]map[string]interface{
 	}
 
 	bigQuery["path"] = destination.Path
+	bigQuery["write_disposition"] = destination.WriteDisposition
+	bigQuery["create_disposition"] = destination.CreateDisposition
 
 	return []map[string]interface{}{bigQuery}, nil
 }
@@ -508,6 +788,8 @@ func parseHiveDestination(destination *Destination) ([]map[string]interface{}, e
 
 	hive := make(map[string]interface{})
 	hive["database"] = destination.Database
+	hive["table_properties"] = destination.TableProperties
+	hive["partition_columns"] = destination.PartitionColumns
 
 	hives := make([]map[string]interface{}, 0, 1)
 	hives = append(hives, hive)
@@ -523,6 +805,9 @@ func parseOnlineDestination(destination *Destination) ([]map[string]interface{},
 	online["url"] = destination.URL
 	online["schema"] = destination.Schema
 
+	if destination.CredentialsProvider == nil {
+		return nil, fmt.Errorf("Destination %q of type %s has no credentials provider", destination.Name, destination.Type)
+	}
 	credentialsProvider, err := parseLoginCredentialsProviderConfig(destination.CredentialsProvider)
 	if err != nil {
 		return nil, err
@@ -548,6 +833,101 @@ func parseBigtableDestination(destination *Destination) ([]map[string]interface{
 	return onlines, nil
 }
 
+func parseDynamoDBDestination(destination *Destination) ([]map[string]interface{}, error) {
+	if destination == nil {
+		return nil, errors.New("Destination is null")
+	}
+
+	dynamoDB := make(map[string]interface{})
+	dynamoDB["table_name"] = destination.TableName
+	dynamoDB["region"] = destination.Region
+	dynamoDB["endpoint"] = destination.Endpoint
+
+	if destination.CredentialsProvider != nil {
+		credentialsProvider, err := parseLoginCredentialsProviderConfig(destination.CredentialsProvider)
+		if err != nil {
+			return nil, err
+		}
+		dynamoDB["credentials_provider"] = []map[string]interface{}{credentialsProvider}
+	} else {
+		dynamoDB["credentials_provider"] = []map[string]interface{}{}
+	}
+
+	dynamoDBs := make([]map[string]interface{}, 0, 1)
+	dynamoDBs = append(dynamoDBs, dynamoDB)
+	return dynamoDBs, nil
+}
+
+func parseCassandraDestination(destination *Destination) ([]map[string]interface{}, error) {
+	if destination == nil {
+		return nil, errors.New("Destination is null")
+	}
+
+	cassandra := make(map[string]interface{})
+	cassandra["contact_points"] = destination.ContactPoints
+	cassandra["keyspace"] = destination.Keyspace
+	cassandra["table"] = destination.Table
+
+	if destination.CredentialsProvider != nil {
+		credentialsProvider, err := parseLoginCredentialsProviderConfig(destination.CredentialsProvider)
+		if err != nil {
+			return nil, err
+		}
+		cassandra["credentials_provider"] = []map[string]interface{}{credentialsProvider}
+	} else {
+		cassandra["credentials_provider"] = []map[string]interface{}{}
+	}
+
+	cassandras := make([]map[string]interface{}, 0, 1)
+	cassandras = append(cassandras, cassandra)
+	return cassandras, nil
+}
+
+// The server's copy of a literal password isn't read back, the configured
+// value is kept in state instead.
+func parseRedisDestination(d *schema.ResourceData, destination *Destination) ([]map[string]interface{}, error) {
+	if destination == nil {
+		return nil, errors.New("Destination is null")
+	}
+
+	redis := make(map[string]interface{})
+	redis["host"] = destination.Host
+	redis["port"] = destination.Port
+	redis["key_prefix"] = destination.KeyPrefix
+
+	if destination.Password != nil {
+		password, err := parseSecretProviderConfig(destination.Password)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := password["value"]; ok {
+			password["value"] = d.Get("redis.0.password.0.value")
+		}
+		redis["password"] = []map[string]interface{}{password}
+	} else {
+		redis["password"] = []map[string]interface{}{}
+	}
+
+	redises := make([]map[string]interface{}, 0, 1)
+	redises = append(redises, redis)
+	return redises, nil
+}
+
+func parsePostgresDestination(destination *Destination) ([]map[string]interface{}, error) {
+	postgres, err := parseJDBCDestination(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	writeMode := destination.WriteMode
+	if writeMode == "" {
+		writeMode = "append"
+	}
+	postgres[0]["write_mode"] = writeMode
+	postgres[0]["conflict_columns"] = destination.ConflictColumns
+	return postgres, nil
+}
+
 func parseKafkaDestination(destination *Destination) ([]map[string]interface{}, error) {
 	if destination == nil {
 		return nil, errors.New("Destination is null")
@@ -585,6 +965,9 @@ func parseSnowflakeDestination(destination *Destination) ([]map[string]interface
 	snowflake["database"] = destination.Database
 	snowflake["warehouse"] = destination.Warehouse
 
+	if destination.CredentialsProvider == nil {
+		return nil, fmt.Errorf("Destination %q of type %s has no credentials provider", destination.Name, destination.Type)
+	}
 	credentialsProvider, err := parseLoginCredentialsProviderConfig(destination.CredentialsProvider)
 	if err != nil {
 		return nil, err
@@ -596,24 +979,32 @@ func parseSnowflakeDestination(destination *Destination) ([]map[string]interface
 	return snowflakes, nil
 }
 
-func composeDestination(d *schema.ResourceData) (*Destination, error) {
+func composeDestination(d *schema.ResourceData, c *Client) (*Destination, error) {
+	accessRules, err := expandAccessRules(d.Get("access_rule").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
 	if s3, _ := expandSingleMap(d.Get("s3")); s3 != nil {
-		fileFormat := composeFileFormat(s3)
+		fileFormat := composeFileFormat(d, "s3")
 		destination := Destination{
 			Name:        d.Get("name").(string),
 			Description: d.Get("description").(string),
 			Type:        "s3",
 			Bucket:      s3["bucket"].(string),
 			Path:        s3["path"].(string),
+			Endpoint:    s3["endpoint"].(string),
+			Region:      s3["region"].(string),
 			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
+			Labels:      expandLabels(d, c),
+			Attributes:  expandAttributes(d, c),
+			AccessRules: accessRules,
 		}
 		return &destination, nil
 	}
 
 	if s3a, _ := expandSingleMap(d.Get("s3a")); s3a != nil {
-		fileFormat := composeFileFormat(s3a)
+		fileFormat := composeFileFormat(d, "s3a")
 		destination := Destination{
 			Name:        d.Get("name").(string),
 			Description: d.Get("description").(string),
@@ -624,8 +1015,9 @@ func composeDestination(d *schema.ResourceData) (*Destination, error) {
 			AccessKey:   s3a["access_key"].(string),
 			SecretKey:   s3a["secret_key"].(string),
 			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
+			Labels:      expandLabels(d, c),
+			Attributes:  expandAttributes(d, c),
+			AccessRules: accessRules,
 		}
 		return &destination, nil
 	}
@@ -648,20 +1040,51 @@ func composeDestination(d *schema.ResourceData) (*Destination, error) {
 			URL:                 jdbc["url"].(string),
 			Schema:              jdbc["schema"].(string),
 			CredentialsProvider: credentialsProvider,
-			Labels:              expandLabels(d),
-			Attributes:          expandAttributes(d),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
+		}
+		return &destination, nil
+	}
+
+	if postgres, _ := expandSingleMap(d.Get("postgres")); postgres != nil {
+		credentialsProviderMap, err := expandSingleMap(postgres["credentials_provider"])
+		if err != nil {
+			return nil, err
+		}
+
+		credentialsProvider, err := composeLoginCredentialsProviderConfig(credentialsProviderMap)
+		if err != nil {
+			return nil, err
+		}
+
+		destination := Destination{
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Type:                "postgres",
+			URL:                 postgres["url"].(string),
+			Schema:              postgres["schema"].(string),
+			CredentialsProvider: credentialsProvider,
+			WriteMode:           postgres["write_mode"].(string),
+			ConflictColumns:     expandStringList(postgres["conflict_columns"].([]interface{})),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
 		}
 		return &destination, nil
 	}
 
 	if hive, _ := expandSingleMap(d.Get("hive")); hive != nil {
 		destination := Destination{
-			Name:        d.Get("name").(string),
-			Description: d.Get("description").(string),
-			Type:        "hive",
-			Database:    hive["database"].(string),
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
+			Name:             d.Get("name").(string),
+			Description:      d.Get("description").(string),
+			Type:             "hive",
+			Database:         hive["database"].(string),
+			TableProperties:  expandStringMap(hive["table_properties"].(map[string]interface{})),
+			PartitionColumns: expandStringList(hive["partition_columns"].([]interface{})),
+			Labels:           expandLabels(d, c),
+			Attributes:       expandAttributes(d, c),
+			AccessRules:      accessRules,
 		}
 		return &destination, nil
 	}
@@ -673,19 +1096,22 @@ func composeDestination(d *schema.ResourceData) (*Destination, error) {
 		}
 
 		destination := Destination{
-			Name:        d.Get("name").(string),
-			Description: d.Get("description").(string),
-			Type:        "bigquery",
-			Path:        bigQuery["path"].(string),
-			StagingArea: stagingArea,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
+			Name:              d.Get("name").(string),
+			Description:       d.Get("description").(string),
+			Type:              "bigquery",
+			Path:              bigQuery["path"].(string),
+			StagingArea:       stagingArea,
+			WriteDisposition:  bigQuery["write_disposition"].(string),
+			CreateDisposition: bigQuery["create_disposition"].(string),
+			Labels:            expandLabels(d, c),
+			Attributes:        expandAttributes(d, c),
+			AccessRules:       accessRules,
 		}
 		return &destination, nil
 	}
 
 	if gcs, _ := expandSingleMap(d.Get("gcs")); gcs != nil {
-		fileFormat := composeFileFormat(gcs)
+		fileFormat := composeFileFormat(d, "gcs")
 		destination := Destination{
 			Name:        d.Get("name").(string),
 			Description: d.Get("description").(string),
@@ -693,36 +1119,39 @@ func composeDestination(d *schema.ResourceData) (*Destination, error) {
 			Bucket:      gcs["bucket"].(string),
 			Path:        gcs["path"].(string),
 			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
+			Labels:      expandLabels(d, c),
+			Attributes:  expandAttributes(d, c),
+			AccessRules: accessRules,
 		}
 		return &destination, nil
 	}
 
 	if local, _ := expandSingleMap(d.Get("local")); local != nil {
-		fileFormat := composeFileFormat(local)
+		fileFormat := composeFileFormat(d, "local")
 		destination := Destination{
 			Name:        d.Get("name").(string),
 			Description: d.Get("description").(string),
 			Type:        "local",
 			Path:        local["path"].(string),
 			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
+			Labels:      expandLabels(d, c),
+			Attributes:  expandAttributes(d, c),
+			AccessRules: accessRules,
 		}
 		return &destination, nil
 	}
 
 	if hdfs, _ := expandSingleMap(d.Get("hdfs")); hdfs != nil {
-		fileFormat := composeFileFormat(hdfs)
+		fileFormat := composeFileFormat(d, "hdfs")
 		destination := Destination{
 			Name:        d.Get("name").(string),
 			Description: d.Get("description").(string),
 			Type:        "hdfs",
 			Path:        hdfs["path"].(string),
 			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
+			Labels:      expandLabels(d, c),
+			Attributes:  expandAttributes(d, c),
+			AccessRules: accessRules,
 		}
 		return &destination, nil
 	}
@@ -745,8 +1174,9 @@ func composeDestination(d *schema.ResourceData) (*Destination, error) {
 			URL:                 online["url"].(string),
 			Schema:              online["schema"].(string),
 			CredentialsProvider: credentialsProvider,
-			Labels:              expandLabels(d),
-			Attributes:          expandAttributes(d),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
 		}
 		return &destination, nil
 	}
@@ -758,8 +1188,84 @@ func composeDestination(d *schema.ResourceData) (*Destination, error) {
 			Type:        "bigtable",
 			Project:     bigtable["project"].(string),
 			Instance:    bigtable["instance"].(string),
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
+			Labels:      expandLabels(d, c),
+			Attributes:  expandAttributes(d, c),
+			AccessRules: accessRules,
+		}
+		return &destination, nil
+	}
+
+	if dynamoDB, _ := expandSingleMap(d.Get("dynamodb")); dynamoDB != nil {
+		var credentialsProvider *LoginCredentialsProviderConfig
+		if credentialsProviderMap, _ := expandSingleMap(dynamoDB["credentials_provider"]); credentialsProviderMap != nil {
+			var err error
+			credentialsProvider, err = composeLoginCredentialsProviderConfig(credentialsProviderMap)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		destination := Destination{
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Type:                "dynamodb",
+			TableName:           dynamoDB["table_name"].(string),
+			Region:              dynamoDB["region"].(string),
+			Endpoint:            dynamoDB["endpoint"].(string),
+			CredentialsProvider: credentialsProvider,
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
+		}
+		return &destination, nil
+	}
+
+	if cassandra, _ := expandSingleMap(d.Get("cassandra")); cassandra != nil {
+		var credentialsProvider *LoginCredentialsProviderConfig
+		if credentialsProviderMap, _ := expandSingleMap(cassandra["credentials_provider"]); credentialsProviderMap != nil {
+			var err error
+			credentialsProvider, err = composeLoginCredentialsProviderConfig(credentialsProviderMap)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		destination := Destination{
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Type:                "cassandra",
+			ContactPoints:       cassandra["contact_points"].(string),
+			Keyspace:            cassandra["keyspace"].(string),
+			Table:               cassandra["table"].(string),
+			CredentialsProvider: credentialsProvider,
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
+		}
+		return &destination, nil
+	}
+
+	if redis, _ := expandSingleMap(d.Get("redis")); redis != nil {
+		var password *SecretValueConfig
+		if passwordMap, _ := expandSingleMap(redis["password"]); passwordMap != nil {
+			var err error
+			password, err = composeSecretValueConfig(passwordMap)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		destination := Destination{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+			Type:        "redis",
+			Host:        redis["host"].(string),
+			Port:        redis["port"].(int),
+			Password:    password,
+			KeyPrefix:   redis["key_prefix"].(string),
+			Labels:      expandLabels(d, c),
+			Attributes:  expandAttributes(d, c),
+			AccessRules: accessRules,
 		}
 		return &destination, nil
 	}
@@ -793,8 +1299,9 @@ func composeDestination(d *schema.ResourceData) (*Destination, error) {
 			BootstrapServers:  kafka["bootstrap_servers"].(string),
 			SchemaRegistryURL: kafka["schema_registry_url"].(string),
 			KafkaProperties:   sensitives,
-			Labels:            expandLabels(d),
-			Attributes:        expandAttributes(d),
+			Labels:            expandLabels(d, c),
+			Attributes:        expandAttributes(d, c),
+			AccessRules:       accessRules,
 		}
 		return &destination, nil
 	}
@@ -819,13 +1326,14 @@ func composeDestination(d *schema.ResourceData) (*Destination, error) {
 			Warehouse:           snowflake["warehouse"].(string),
 			Database:            snowflake["database"].(string),
 			CredentialsProvider: credentialsProvider,
-			Labels:              expandLabels(d),
-			Attributes:          expandAttributes(d),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
 		}
 		return &destination, nil
 	}
 
-	return nil, errors.New("Invalid destination type")
+	return nil, invalidBlockTypeError("destination", d, destinationTypes)
 }
 
 func composeGCSStagingArea(d map[string]interface{}) (*GCSStagingArea, error) {
@@ -0,0 +1,120 @@
+package anaml
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestParseBucketDestinationSetsEveryBucketType(t *testing.T) {
+	parsers := []struct {
+		key   string
+		parse func(*Destination) ([]map[string]interface{}, error)
+	}{
+		{"s3", parseS3Destination},
+		{"s3a", parseS3ADestination},
+		{"gcs", parseS3Destination},
+		{"local", parseLocalDestination},
+		{"hdfs", parseLocalDestination},
+	}
+
+	for _, tt := range parsers {
+		t.Run(tt.key, func(t *testing.T) {
+			destination := &Destination{
+				Name:       "example",
+				Type:       tt.key,
+				Bucket:     "bucket",
+				Path:       "/path",
+				Endpoint:   "https://minio.example.com",
+				Region:     "ap-southeast-2",
+				FileFormat: &FileFormat{Type: "parquet"},
+			}
+			parsed, err := tt.parse(destination)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := schema.TestResourceDataRaw(t, ResourceDestination().Schema, map[string]interface{}{})
+			if err := d.Set(tt.key, parsed); err != nil {
+				t.Fatalf("setting %s: %v", tt.key, err)
+			}
+			if got := d.Get(tt.key + ".0.file_format"); got != "parquet" {
+				t.Errorf("file_format = %v, want parquet", got)
+			}
+		})
+	}
+}
+
+func TestParseS3DestinationKeepsEndpointAndRegion(t *testing.T) {
+	destination := &Destination{
+		Type:       "s3",
+		Bucket:     "bucket",
+		Path:       "/path",
+		Endpoint:   "https://minio.example.com",
+		Region:     "ap-southeast-2",
+		FileFormat: &FileFormat{Type: "parquet"},
+	}
+	parsed, err := parseS3Destination(destination)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, ResourceDestination().Schema, map[string]interface{}{})
+	if err := d.Set("s3", parsed); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("s3.0.endpoint"); got != destination.Endpoint {
+		t.Errorf("endpoint = %v, want %v", got, destination.Endpoint)
+	}
+	if got := d.Get("s3.0.region"); got != destination.Region {
+		t.Errorf("region = %v, want %v", got, destination.Region)
+	}
+}
+
+func TestParseDestinationWithoutRequiredBlock(t *testing.T) {
+	cases := []struct {
+		name    string
+		typ     string
+		parse   func(*Destination) ([]map[string]interface{}, error)
+		wantErr string
+	}{
+		{"s3", "s3", parseS3Destination, `Destination "features" of type s3 has no file format`},
+		{"gcs", "gcs", parseS3Destination, `Destination "features" of type gcs has no file format`},
+		{"s3a", "s3a", parseS3ADestination, `Destination "features" of type s3a has no file format`},
+		{"local", "local", parseLocalDestination, `Destination "features" of type local has no file format`},
+		{"jdbc", "jdbc", parseJDBCDestination, `Destination "features" of type jdbc has no credentials provider`},
+		{"online", "online", parseOnlineDestination, `Destination "features" of type online has no credentials provider`},
+		{"snowflake", "snowflake", parseSnowflakeDestination, `Destination "features" of type snowflake has no credentials provider`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parse(&Destination{Name: "features", Type: tt.typ})
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseDestinationPassesCredentialsErrorsThrough(t *testing.T) {
+	cases := []struct {
+		name  string
+		typ   string
+		parse func(*Destination) ([]map[string]interface{}, error)
+	}{
+		{"jdbc", "jdbc", parseJDBCDestination},
+		{"online", "online", parseOnlineDestination},
+		{"snowflake", "snowflake", parseSnowflakeDestination},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parse(&Destination{Name: "features", Type: tt.typ, CredentialsProvider: &LoginCredentialsProviderConfig{Type: "kerberos"}})
+			want := "LoginCredentialsProviderConfig.Type contains an unexpected value: kerberos"
+			if err == nil || err.Error() != want {
+				t.Errorf("err = %v, want %q", err, want)
+			}
+		})
+	}
+}
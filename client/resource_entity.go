@@ -1,9 +1,14 @@
 package anaml
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
@@ -27,47 +32,59 @@ Features will be generated for a specific Entity. This means the aggregation wil
 
 func ResourceEntity() *schema.Resource {
 	return &schema.Resource{
-		Description: entityDescription,
-		Create:      resourceEntityCreate,
-		Read:        resourceEntityRead,
-		Update:      resourceEntityUpdate,
-		Delete:      resourceEntityDelete,
+		Description:   entityDescription,
+		CreateContext: withWarnings(resourceEntityCreate),
+		ReadContext:   withDiagnostics(resourceEntityRead),
+		UpdateContext: withWarnings(resourceEntityUpdate),
+		DeleteContext: withDiagnostics(resourceEntityDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("entity", (*Client).FindEntityByName),
 		},
+		CustomizeDiff: customizeEntityDiff,
 
-		Schema: map[string]*schema.Schema{
+		Schema: auditSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"default_column": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ExactlyOneOf: []string{"default_column", "entities"},
+				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"required_type": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"entities"},
+				ConflictsWith: []string{"entities", "required_type_json"},
 				ValidateFunc: validation.StringInSlice([]string{
 					"string", "integer", "long", "binary",
 				}, false),
 				Description: "The data type the entity is encoded as. If set, tables' entity columns must be of this type. One of string, integer, long, or binary",
 			},
+			"required_type_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"entities", "required_type"},
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				Description:      "A complex data type, such as a struct or array, the entity is encoded as, given as the server's JSON encoding of the type. Use required_type for primitive types",
+			},
 			"entities": {
 				Type:        schema.TypeList,
 				Description: "Entities from which this composite entity is derived",
 				Optional:    true,
 
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 			"labels": {
@@ -82,10 +99,53 @@ func ResourceEntity() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
-		},
+			"deletion_protection": deletionProtectionSchema(),
+		}),
 	}
 }
 
+// Composite entities must be made of at least two entities, and can't
+// (through their members' own members) be made of themselves.
+func customizeEntityDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	c := m.(*Client)
+
+	members, _ := d.Get("entities").([]interface{})
+	if d.Get("default_column").(string) != "" || len(members) == 0 {
+		return nil
+	}
+	if len(members) < 2 {
+		return errors.New("A composite entity must have at least two entities, use default_column for a base entity")
+	}
+
+	entityID := d.Id()
+	if entityID == "" || !d.NewValueKnown("entities") {
+		return nil
+	}
+
+	visited := make(map[string]bool)
+	pending := expandStringList(members)
+	for len(pending) > 0 {
+		memberID := pending[0]
+		pending = pending[1:]
+		if memberID == entityID {
+			return fmt.Errorf("Composite entity %s can't be derived from itself", entityID)
+		}
+		if visited[memberID] {
+			continue
+		}
+		visited[memberID] = true
+
+		member, err := c.GetEntity(memberID)
+		if err != nil {
+			return err
+		}
+		if member != nil && member.Entities != nil {
+			pending = append(pending, identifierList(*member.Entities)...)
+		}
+	}
+	return nil
+}
+
 func resourceEntityRead(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	entityID := d.Id()
@@ -110,23 +170,15 @@ func resourceEntityRead(d *schema.ResourceData, m interface{}) error {
 			return err
 		}
 
-		if entity.RequiredType != nil {
-			derefed := *(entity.RequiredType)
-			requiredTypeString, ok := derefed.(string)
-
-			if ok {
-				if err := d.Set("required_type", requiredTypeString); err != nil {
-					return err
-				}
-			} else {
-				if err := d.Set("required_type", "Complex Type"); err != nil {
-					return err
-				}
-			}
-		} else {
-			if err := d.Set("required_type", nil); err != nil {
-				return err
-			}
+		requiredType, requiredTypeJSON, err := flattenRequiredType(entity.RequiredType)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("required_type", requiredType); err != nil {
+			return err
+		}
+		if err := d.Set("required_type_json", requiredTypeJSON); err != nil {
+			return err
 		}
 		if err := d.Set("entities", nil); err != nil {
 			return err
@@ -139,71 +191,117 @@ func resourceEntityRead(d *schema.ResourceData, m interface{}) error {
 		if err := d.Set("required_type", nil); err != nil {
 			return err
 		}
+		if err := d.Set("required_type_json", nil); err != nil {
+			return err
+		}
 		if err := d.Set("entities", identifierList(*entity.Entities)); err != nil {
 			return err
 		}
 	}
-	if err := d.Set("labels", entity.Labels); err != nil {
+	if err := setAuditMetadata(d, entity.AuditMetadata); err != nil {
+		return err
+	}
+	if err := d.Set("deletion_protection", d.Get("deletion_protection").(bool)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(entity.Attributes)); err != nil {
+	if err := d.Set("labels", flattenLabels(d, c, entity.Labels)); err != nil {
+		return err
+	}
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, entity.Attributes))); err != nil {
 		return err
 	}
 	return err
 }
 
-func buildEntity(d *schema.ResourceData) Entity {
+// Primitive required types are plain strings, and complex ones are
+// objects which are kept as their JSON encoding.
+func flattenRequiredType(requiredType *interface{}) (string, string, error) {
+	if requiredType == nil || *requiredType == nil {
+		return "", "", nil
+	}
+	if primitive, ok := (*requiredType).(string); ok {
+		return primitive, "", nil
+	}
+	encoded, err := json.Marshal(*requiredType)
+	if err != nil {
+		return "", "", err
+	}
+	return "", string(encoded), nil
+}
+
+func buildEntity(d *schema.ResourceData, c *Client) (*Entity, error) {
 	entity := Entity{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Labels:      expandLabels(d),
-		Attributes:  expandAttributes(d),
+		Labels:      expandLabels(d, c),
+		Attributes:  expandAttributes(d, c),
 	}
 
-	if default_column := d.Get("default_column").(string); default_column != "" {
+	if default_column, set := d.GetOk("default_column"); set {
+		default_column := default_column.(string)
 		entity.Type = "base"
 		entity.DefaultColumn = &default_column
 		if required_type, set := d.GetOk("required_type"); set {
 			required_type := required_type
 			entity.RequiredType = &required_type
 		}
-	} else {
-		entities := expandIdentifierList(d.Get("entities").([]interface{}))
+		if required_type_json, set := d.GetOk("required_type_json"); set {
+			var required_type interface{}
+			if err := json.Unmarshal([]byte(required_type_json.(string)), &required_type); err != nil {
+				return nil, err
+			}
+			entity.RequiredType = &required_type
+		}
+	} else if members, set := d.GetOk("entities"); set {
+		entities := expandIdentifierList(members.([]interface{}))
 		entity.Type = "composite"
 		entity.Entities = &entities
+	} else {
+		return nil, errors.New("An entity needs a non-empty default_column for a base entity, or entities for a composite entity")
 	}
 
-	return entity
+	return &entity, nil
 }
 
-func resourceEntityCreate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	entity := buildEntity(d)
-	e, err := c.CreateEntity(entity)
+	entity, err := buildEntity(d, c)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	e, warnings, err := c.CreateEntity(*entity)
+	if err != nil {
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceEntityRead)
 }
 
-func resourceEntityUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	entityID := d.Id()
-	entity := buildEntity(d)
-	err := c.UpdateEntity(entityID, entity)
+	entity, err := buildEntity(d, c)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	warnings, err := c.UpdateEntity(entityID, *entity)
+	if err != nil {
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceEntityRead(d, m)
 }
 
 func resourceEntityDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	entityID := d.Id()
 
+	if err := checkDeletionProtection(d, "Entity"); err != nil {
+		return err
+	}
+
 	err := c.DeleteEntity(entityID)
 	if err != nil {
 		return err
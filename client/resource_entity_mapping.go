@@ -27,30 +27,33 @@ you could define an Entity Mapping from Account to Customer:
 
 func ResourceEntityMapping() *schema.Resource {
 	return &schema.Resource{
-		Description: entityMappingDescription,
-		Create:      resourceEntityMappingCreate,
-		Read:        resourceEntityMappingRead,
-		Update:      resourceEntityMappingUpdate,
-		Delete:      resourceEntityMappingDelete,
+		Description:   entityMappingDescription,
+		CreateContext: withWarnings(resourceEntityMappingCreate),
+		ReadContext:   withDiagnostics(resourceEntityMappingRead),
+		UpdateContext: withWarnings(resourceEntityMappingUpdate),
+		DeleteContext: withDiagnostics(resourceEntityMappingDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"from": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"to": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"mapping": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 
 			"one_to_many": {
@@ -58,7 +61,7 @@ func ResourceEntityMapping() *schema.Resource {
 				Optional:    true,
 				MaxItems:    1,
 				Elem:        &schema.Resource{},
-				Description: "The mapping feature produces an array of keys which are related.",
+				Description: "The mapping feature produces an array of keys which are related. If neither this nor `one_to_one` is set, the direction is left unspecified.",
 			},
 			"one_to_one": {
 				Type:          schema.TypeList,
@@ -106,7 +109,7 @@ func resourceEntityMappingRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceEntityMappingCreate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityMappingCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 	from, _ := strconv.Atoi(d.Get("from").(string))
 	to, _ := strconv.Atoi(d.Get("to").(string))
@@ -119,17 +122,17 @@ func resourceEntityMappingCreate(d *schema.ResourceData, m interface{}) error {
 		OneToMany: booleanEmptys(d.Get("one_to_one").([]interface{}), d.Get("one_to_many").([]interface{})),
 	}
 
-	e, err := c.CreateEntityMapping(mapping)
+	e, warnings, err := c.CreateEntityMapping(mapping)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceEntityMappingRead)
 }
 
-func resourceEntityMappingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityMappingUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	mappingID := d.Id()
 	from, _ := strconv.Atoi(d.Get("from").(string))
 	to, _ := strconv.Atoi(d.Get("to").(string))
@@ -142,12 +145,13 @@ func resourceEntityMappingUpdate(d *schema.ResourceData, m interface{}) error {
 		OneToMany: booleanEmptys(d.Get("one_to_one").([]interface{}), d.Get("one_to_many").([]interface{})),
 	}
 
-	err := c.UpdateEntityMapping(mappingID, mapping)
+	warnings, err := c.UpdateEntityMapping(mappingID, mapping)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceEntityMappingRead(d, m)
 }
 
 func resourceEntityMappingDelete(d *schema.ResourceData, m interface{}) error {
@@ -162,6 +166,9 @@ func resourceEntityMappingDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// The direction of an entity mapping is a nullable boolean on the server.
+// It's represented as two mutually exclusive empty blocks so that
+// omitting both serialises as absent rather than as false.
 func booleanEmptys(falses []interface{}, trues []interface{}) *bool {
 	if len(falses) > 0 {
 		ret := false
@@ -174,16 +181,8 @@ func booleanEmptys(falses []interface{}, trues []interface{}) *bool {
 	return nil
 }
 
-func flattenBooleanEmptys(p *bool) ([]interface{}, []interface{}) {
-	falses := make([]interface{}, 0, 1)
-	trues := make([]interface{}, 0, 1)
-
-	if p == nil {
-	} else if *p {
-		trues = append(falses, &struct{}{})
-	} else {
-		falses = append(trues, &struct{}{})
-	}
-
+func flattenBooleanEmptys(p *bool) ([]map[string]interface{}, []map[string]interface{}) {
+	falses := buildEmpty(p != nil && !*p)
+	trues := buildEmpty(p != nil && *p)
 	return falses, trues
 }
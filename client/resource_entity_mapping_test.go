@@ -0,0 +1,102 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestEntityMappingOneToMany(t *testing.T) {
+	empty := []interface{}{map[string]interface{}{}}
+	cases := []struct {
+		name      string
+		direction map[string]interface{}
+		wantSent  string
+	}{
+		{"unset", nil, ""},
+		{"one to one", map[string]interface{}{"one_to_one": empty}, "false"},
+		{"one to many", map[string]interface{}{"one_to_many": empty}, "true"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// The server echoes back what was created, which omits
+			// oneToMany when it wasn't sent.
+			var created []byte
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/entity-mapping":
+					created, _ = ioutil.ReadAll(r.Body)
+					w.Write([]byte(`5`))
+				case r.Method == "GET" && r.URL.Path == "/entity-mapping/5":
+					w.Write(created)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			config := map[string]interface{}{"from": "1", "to": "2", "mapping": "3"}
+			for k, v := range tt.direction {
+				config[k] = v
+			}
+
+			d := schema.TestResourceDataRaw(t, ResourceEntityMapping().Schema, config)
+			if diags := ResourceEntityMapping().CreateContext(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("diags = %v", diags)
+			}
+
+			var sent map[string]json.RawMessage
+			if err := json.Unmarshal(created, &sent); err != nil {
+				t.Fatal(err)
+			}
+			if got := string(sent["oneToMany"]); got != tt.wantSent {
+				t.Errorf("sent oneToMany = %q, want %q", got, tt.wantSent)
+			}
+
+			diff, err := ResourceEntityMapping().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !diff.Empty() {
+				t.Errorf("diff = %v, want no drift", diff)
+			}
+		})
+	}
+}
+
+func TestEntityMappingIdentifiersCompareByValue(t *testing.T) {
+	cases := []struct {
+		name      string
+		from      string
+		wantEmpty bool
+	}{
+		{"same", "7", true},
+		{"leading zeros", "007", true},
+		{"different", "8", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceEntityMapping().Schema, map[string]interface{}{"from": "7", "to": "2", "mapping": "3"})
+			d.SetId("5")
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{"from": tt.from, "to": "2", "mapping": "3"})
+			if diags := ResourceEntityMapping().Validate(config); diags.HasError() {
+				t.Fatalf("diags = %v", diags)
+			}
+			diff, err := ResourceEntityMapping().Diff(context.Background(), d.State(), config, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.Empty() != tt.wantEmpty {
+				t.Errorf("diff = %v, want empty %v", diff, tt.wantEmpty)
+			}
+		})
+	}
+}
@@ -18,13 +18,13 @@ the second of which is named "date".
 
 func ResourceEntityPopulation() *schema.Resource {
 	return &schema.Resource{
-		Description: entityPopulationsDescription,
-		Create:      resourceEntityPopulationCreate,
-		Read:        resourceEntityPopulationRead,
-		Update:      resourceEntityPopulationUpdate,
-		Delete:      resourceEntityPopulationDelete,
+		Description:   entityPopulationsDescription,
+		CreateContext: withWarnings(resourceEntityPopulationCreate),
+		ReadContext:   withDiagnostics(resourceEntityPopulationRead),
+		UpdateContext: withWarnings(resourceEntityPopulationUpdate),
+		DeleteContext: withDiagnostics(resourceEntityPopulationDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("entity population", (*Client).FindEntityPopulationByName),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -34,8 +34,9 @@ func ResourceEntityPopulation() *schema.Resource {
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"labels": {
 				Type:        schema.TypeSet,
@@ -50,18 +51,20 @@ func ResourceEntityPopulation() *schema.Resource {
 				Elem:        attributeSchema(),
 			},
 			"entity": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
-				Description:  "The type of entity this population describes",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
+				Description:      "The type of entity this population describes",
 			},
 			"sources": {
 				Type:        schema.TypeList,
 				Description: "Tables upon which this entity population is created",
 				Required:    true,
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 			"expression": {
@@ -92,10 +95,10 @@ func resourceEntityPopulationRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("description", population.Description); err != nil {
 		return err
 	}
-	if err := d.Set("labels", population.Labels); err != nil {
+	if err := d.Set("labels", flattenLabels(d, c, population.Labels)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(population.Attributes)); err != nil {
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, population.Attributes))); err != nil {
 		return err
 	}
 	if err := d.Set("entity", strconv.Itoa(population.Entity)); err != nil {
@@ -111,28 +114,29 @@ func resourceEntityPopulationRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceEntityPopulationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityPopulationCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	population := buildPopulation(d)
-	e, err := c.CreateEntityPopulation(population)
+	population := buildPopulation(d, c)
+	e, warnings, err := c.CreateEntityPopulation(population)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceEntityPopulationRead)
 }
 
-func resourceEntityPopulationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityPopulationUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	populationID := d.Id()
-	population := buildPopulation(d)
-	err := c.UpdateEntityPopulation(populationID, population)
+	population := buildPopulation(d, c)
+	warnings, err := c.UpdateEntityPopulation(populationID, population)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceEntityPopulationRead(d, m)
 }
 
 func resourceEntityPopulationDelete(d *schema.ResourceData, m interface{}) error {
@@ -147,13 +151,13 @@ func resourceEntityPopulationDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func buildPopulation(d *schema.ResourceData) EntityPopulation {
+func buildPopulation(d *schema.ResourceData, c *Client) EntityPopulation {
 	entity, _ := strconv.Atoi(d.Get("entity").(string))
 	return EntityPopulation{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Labels:      expandLabels(d),
-		Attributes:  expandAttributes(d),
+		Labels:      expandLabels(d, c),
+		Attributes:  expandAttributes(d, c),
 		Entity:      entity,
 		Expression:  d.Get("expression").(string),
 		Sources:     expandIdentifierList(d.Get("sources").([]interface{})),
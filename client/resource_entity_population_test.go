@@ -0,0 +1,60 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEntityPopulationEmptySources(t *testing.T) {
+	cases := []struct {
+		name        string
+		readSources string
+	}{
+		{"read back as empty list", `[]`},
+		{"read back as null", `null`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent map[string]json.RawMessage
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/entity-population":
+					body, _ := ioutil.ReadAll(r.Body)
+					if err := json.Unmarshal(body, &sent); err != nil {
+						t.Errorf("request body %s: %v", body, err)
+					}
+					w.Write([]byte(`4`))
+				case r.Method == "GET" && r.URL.Path == "/entity-population/4":
+					w.Write([]byte(`{"id": 4, "name": "everyone", "entity": 2, "expression": "SELECT 1", "labels": [], "attributes": [], "sources": ` + tt.readSources + `}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, ResourceEntityPopulation().Schema, map[string]interface{}{
+				"name":       "everyone",
+				"entity":     "2",
+				"expression": "SELECT 1",
+				"sources":    []interface{}{},
+			})
+			if diags := ResourceEntityPopulation().CreateContext(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("diags = %v", diags)
+			}
+
+			if got := string(sent["sources"]); got != "[]" {
+				t.Errorf("sent sources = %s, want []", got)
+			}
+			sources, ok := d.Get("sources").([]interface{})
+			if !ok || sources == nil || len(sources) != 0 {
+				t.Errorf("sources = %#v, want an empty list", d.Get("sources"))
+			}
+		})
+	}
+}
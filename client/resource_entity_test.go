@@ -0,0 +1,143 @@
+package anaml
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestEntityRequiredTypeRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		server   string
+		wantType string
+		wantJSON string
+	}{
+		{"unset", `null`, "", ""},
+		{"primitive", `"string"`, "string", ""},
+		{"struct", `{"adt_type": "struct", "fields": [{"name": "id", "type": "long"}, {"name": "region", "type": "string"}]}`, "", `{"adt_type":"struct","fields":[{"name":"id","type":"long"},{"name":"region","type":"string"}]}`},
+		{"array", `{"adt_type": "array", "elementType": "string"}`, "", `{"adt_type":"array","elementType":"string"}`},
+		{"nested", `{"adt_type": "array", "elementType": {"adt_type": "struct", "fields": [{"name": "id", "type": "long"}]}}`, "", `{"adt_type":"array","elementType":{"adt_type":"struct","fields":[{"name":"id","type":"long"}]}}`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var requiredType interface{}
+			if err := json.Unmarshal([]byte(tt.server), &requiredType); err != nil {
+				t.Fatal(err)
+			}
+
+			gotType, gotJSON, err := flattenRequiredType(&requiredType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotType != tt.wantType || gotJSON != tt.wantJSON {
+				t.Fatalf("flattenRequiredType(%s) = %q, %q, want %q, %q", tt.server, gotType, gotJSON, tt.wantType, tt.wantJSON)
+			}
+
+			// Building the entity from what was read must send the same
+			// type back to the server.
+			config := map[string]interface{}{"name": "customer", "default_column": "customer_id"}
+			if gotType != "" {
+				config["required_type"] = gotType
+			}
+			if gotJSON != "" {
+				config["required_type_json"] = gotJSON
+			}
+			d := schema.TestResourceDataRaw(t, ResourceEntity().Schema, config)
+			entity, err := buildEntity(d, &Client{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sent interface{}
+			if entity.RequiredType != nil {
+				sent = *entity.RequiredType
+			}
+			if !reflect.DeepEqual(sent, requiredType) {
+				t.Errorf("sent required type %#v, want %#v", sent, requiredType)
+			}
+		})
+	}
+}
+
+func TestEntityRequiredTypeJSONIgnoresFormatting(t *testing.T) {
+	cases := []struct {
+		name     string
+		old, new string
+		suppress bool
+	}{
+		{"reformatted", `{"adt_type":"array","elementType":"string"}`, "{\n  \"elementType\": \"string\",\n  \"adt_type\": \"array\"\n}", true},
+		{"changed", `{"adt_type":"array","elementType":"string"}`, `{"adt_type":"array","elementType":"long"}`, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			suppress := ResourceEntity().Schema["required_type_json"].DiffSuppressFunc
+			if got := suppress("required_type_json", tt.old, tt.new, nil); got != tt.suppress {
+				t.Errorf("suppressed = %v, want %v", got, tt.suppress)
+			}
+		})
+	}
+}
+
+func TestBuildEntityKind(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   map[string]interface{}
+		wantType string
+		wantErr  bool
+	}{
+		{"base", map[string]interface{}{"default_column": "customer_id"}, "base", false},
+		{"composite", map[string]interface{}{"entities": []interface{}{"1", "2"}}, "composite", false},
+		{"empty default_column", map[string]interface{}{"default_column": ""}, "", true},
+		{"empty default_column with entities", map[string]interface{}{"default_column": "", "entities": []interface{}{"1"}}, "composite", false},
+		{"neither", map[string]interface{}{}, "", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["name"] = "customer"
+			d := schema.TestResourceDataRaw(t, ResourceEntity().Schema, tt.config)
+			entity, err := buildEntity(d, &Client{})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("entity = %+v, want an error", entity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if entity.Type != tt.wantType {
+				t.Errorf("type = %q, want %q", entity.Type, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestValidateEntityDefaultColumn(t *testing.T) {
+	cases := []struct {
+		name          string
+		defaultColumn string
+		valid         bool
+	}{
+		{"column", "customer_id", true},
+		{"empty", "", false},
+		{"whitespace", "  ", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := ResourceEntity().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":           "customer",
+				"default_column": tt.defaultColumn,
+			}))
+			if valid := !diags.HasError(); valid != tt.valid {
+				t.Errorf("valid = %v, want %v (diags: %v)", valid, tt.valid, diags)
+			}
+		})
+	}
+}
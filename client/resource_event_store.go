@@ -12,13 +12,13 @@ const eventStoreDescription = `# Event Stores`
 
 func ResourceEventStore() *schema.Resource {
 	return &schema.Resource{
-		Description: eventStoreDescription,
-		Create:      resourceEventStoreCreate,
-		Read:        resourceEventStoreRead,
-		Update:      resourceEventStoreUpdate,
-		Delete:      resourceEventStoreDelete,
+		Description:   eventStoreDescription,
+		CreateContext: withWarnings(resourceEventStoreCreate),
+		ReadContext:   withDiagnostics(resourceEventStoreRead),
+		UpdateContext: withWarnings(resourceEventStoreUpdate),
+		DeleteContext: withDiagnostics(resourceEventStoreDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("event store", (*Client).FindEventStoreByName),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -28,13 +28,14 @@ func ResourceEventStore() *schema.Resource {
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"bootstrap_servers": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ValidateFunc: validateBootstrapServers(),
 			},
 			"schema_registry_url": {
 				Type:         schema.TypeString,
@@ -87,16 +88,19 @@ func ResourceEventStore() *schema.Resource {
 				ConflictsWith: []string{"daily_schedule"},
 			},
 			"cluster": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The ID of the cluster to run on. Defaults to the provider's default_cluster",
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"cluster_property_sets": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 			"access_rules": {
@@ -190,10 +194,10 @@ func resourceEventStoreRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("description", entity.Description); err != nil {
 		return err
 	}
-	if err := d.Set("labels", entity.Labels); err != nil {
+	if err := d.Set("labels", flattenLabels(d, c, entity.Labels)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(entity.Attributes)); err != nil {
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, entity.Attributes))); err != nil {
 		return err
 	}
 	if err := d.Set("bootstrap_servers", entity.BootstrapServers); err != nil {
@@ -220,7 +224,11 @@ func resourceEventStoreRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("glacier_base_uri", entity.GlacierBaseURI); err != nil {
 		return err
 	}
-	if err := d.Set("cluster", strconv.Itoa(entity.Cluster)); err != nil {
+	cluster, err := flattenCluster(d, c, entity.Cluster)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("cluster", cluster); err != nil {
 		return err
 	}
 	if err := d.Set("cluster_property_sets", identifierList(entity.ClusterPropertySets)); err != nil {
@@ -262,35 +270,36 @@ func resourceEventStoreRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceEventStoreCreate(d *schema.ResourceData, m interface{}) error {
+func resourceEventStoreCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	eventStore, err := buildEventStore(d)
+	eventStore, err := buildEventStore(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	e, err := c.CreateEventStore(*eventStore)
+	e, warnings, err := c.CreateEventStore(*eventStore)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceEventStoreRead)
 }
 
-func resourceEventStoreUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceEventStoreUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	eventStoreID := d.Id()
-	eventStore, err := buildEventStore(d)
+	eventStore, err := buildEventStore(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateEventStore(eventStoreID, *eventStore)
+	warnings, err := c.UpdateEventStore(eventStoreID, *eventStore)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceEventStoreRead(d, m)
 }
 
 func resourceEventStoreDelete(d *schema.ResourceData, m interface{}) error {
@@ -305,7 +314,7 @@ func resourceEventStoreDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func buildEventStore(d *schema.ResourceData) (*EventStore, error) {
+func buildEventStore(d *schema.ResourceData, c *Client) (*EventStore, error) {
 	accessRules, err := expandAccessRules(d.Get("access_rules").([]interface{}))
 	if err != nil {
 		return nil, err
@@ -344,7 +353,7 @@ func buildEventStore(d *schema.ResourceData) (*EventStore, error) {
 			HasStreaming: hasStreaming,
 		}
 	}
-	cluster, err := strconv.Atoi(d.Get("cluster").(string))
+	cluster, err := expandCluster(d, c)
 	if err != nil {
 		return nil, err
 	}
@@ -373,8 +382,8 @@ func buildEventStore(d *schema.ResourceData) (*EventStore, error) {
 		BatchIngestBaseURI:  getNullableString(d, "batch_ingest_base_uri"),
 		ScatterBaseURI:      d.Get("scatter_base_uri").(string),
 		GlacierBaseURI:      d.Get("glacier_base_uri").(string),
-		Labels:              expandLabels(d),
-		Attributes:          expandAttributes(d),
+		Labels:              expandLabels(d, c),
+		Attributes:          expandAttributes(d, c),
 		Cluster:             cluster,
 		ClusterPropertySets: expandIdentifierList(d.Get("cluster_property_sets").([]interface{})),
 		Schedule:            schedule,
@@ -1,8 +1,13 @@
 package anaml
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -25,69 +30,91 @@ There are two types of Features:
 
 func ResourceFeature() *schema.Resource {
 	return &schema.Resource{
-		Description: featureDescription,
-		Create:      resourceFeatureCreate,
-		Read:        resourceFeatureRead,
-		Update:      resourceFeatureUpdate,
-		Delete:      resourceFeatureDelete,
+		Description:   featureDescription,
+		CreateContext: withWarnings(resourceFeatureCreate),
+		ReadContext:   withDiagnostics(resourceFeatureRead),
+		UpdateContext: withWarnings(resourceFeatureUpdate),
+		DeleteContext: withDiagnostics(resourceFeatureDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("feature", (*Client).FindFeatureByName),
 		},
+		CustomizeDiff: customizeFeatureDiff,
 
-		Schema: map[string]*schema.Schema{
+		Schema: auditSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"table": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "A reference to a Table ID the feature is derived from.",
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
+			},
+			"select": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "A reference to a Table ID the feature is derived from.",
-				ValidateFunc: validateAnamlIdentifier(),
+				Description:  "An SQL expression for the column to aggregate.",
+				ExactlyOneOf: []string{"select", "select_file"},
 			},
-			"select": {
+			"select_file": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "An SQL expression for the column to aggregate.",
+				Optional:    true,
+				Description: "The path to a file containing the SQL expression for the column to aggregate.",
 			},
 			"filter": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "An SQL column expression to filter with.",
+				ConflictsWith: []string{"filter_file"},
+			},
+			"filter_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "An SQL column expression to filter with.",
+				Description: "The path to a file containing the SQL column expression to filter with.",
 			},
 			"hours": {
 				Type:          schema.TypeInt,
 				Optional:      true,
 				Description:   "The event window description for the number of days to aggregate over.",
-				ConflictsWith: []string{"days", "rows", "months"},
+				ConflictsWith: []string{"days", "rows", "months", "duration"},
 				ValidateFunc:  validation.IntAtLeast(1),
 			},
 			"days": {
 				Type:          schema.TypeInt,
 				Optional:      true,
 				Description:   "The event window description for the number of days to aggregate over.",
-				ConflictsWith: []string{"hours", "rows", "months"},
+				ConflictsWith: []string{"hours", "rows", "months", "duration"},
 				ValidateFunc:  validation.IntAtLeast(1),
 			},
 			"months": {
 				Type:          schema.TypeInt,
 				Optional:      true,
 				Description:   "The event window description for the number of months to aggregate over.",
-				ConflictsWith: []string{"hours", "days", "rows"},
+				ConflictsWith: []string{"hours", "days", "rows", "duration"},
 				ValidateFunc:  validation.IntAtLeast(1),
 			},
 			"rows": {
 				Type:          schema.TypeInt,
 				Optional:      true,
 				Description:   "The event window description for the number of rows (events) to aggregate over.",
-				ConflictsWith: []string{"hours", "days", "months"},
+				ConflictsWith: []string{"hours", "days", "months", "duration"},
 				ValidateFunc:  validation.IntAtLeast(1),
 			},
+			"duration": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The event window to aggregate over as a duration, such as 36h, 90d or 6mo.",
+				ConflictsWith: []string{"hours", "days", "months", "rows"},
+				ValidateFunc:  validateWindowDuration(),
+			},
 			"aggregation": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -105,37 +132,43 @@ func ResourceFeature() *schema.Resource {
 				Description: "An SQL expression to apply to the result of the feature aggregation.",
 			},
 			"entity_restrictions": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "List of entity Id's that the feature is restricted to.",
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "List of entity Id's that the feature is restricted to.",
+				ConflictsWith: []string{"entity_restriction_labels"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"entity_restriction_labels": entityRestrictionLabelsSchema(),
 			"over": {
-				Type:         schema.TypeList,
-				Optional:     true,
-				Description:  "A list of Features this row feature depends on",
-				AtLeastOneOf: []string{"table", "over"},
-				RequiredWith: []string{"entity"},
+				Type:             schema.TypeList,
+				Optional:         true,
+				Description:      "A list of Features this row feature depends on",
+				AtLeastOneOf:     []string{"table", "over"},
+				RequiredWith:     []string{"entity"},
+				DiffSuppressFunc: suppressOverReorderDiff,
 
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 			"entity": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The Entity to map a row feature over.",
-				ValidateFunc: validateAnamlIdentifier(),
-				RequiredWith: []string{"over"},
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The Entity to map a row feature over.",
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
+				RequiredWith:     []string{"over"},
 			},
 			"template": {
-				Type:         schema.TypeString,
-				Description:  "The feature template this feature is derived from.",
-				Optional:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Description:      "The feature template this feature is derived from.",
+				Optional:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"labels": {
 				Type:        schema.TypeSet,
@@ -149,7 +182,8 @@ func ResourceFeature() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
-		},
+			"deletion_protection": deletionProtectionSchema(),
+		}),
 	}
 }
 
@@ -172,11 +206,11 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("description", feature.Description); err != nil {
 		return err
 	}
-	if err := d.Set("select", feature.Select.SQL); err != nil {
+	if err := setSQLExpression(d, "select", feature.Select.SQL); err != nil {
 		return err
 	}
 	if feature.Filter != nil {
-		if err := d.Set("filter", feature.Filter.SQL); err != nil {
+		if err := setSQLExpression(d, "filter", feature.Filter.SQL); err != nil {
 			return err
 		}
 	} else {
@@ -200,8 +234,22 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if feature.Type == "event" {
-		if feature.Window.Type == "hourwindow" {
-			if err := d.Set("hours", feature.Window.Hours); err != nil {
+		// A window written as a duration is kept in that form, with the
+		// explicit window fields left unset.
+		intWindow := feature.Window
+		if duration := flattenWindowDuration(d, feature.Window); duration != "" {
+			intWindow = &EventWindow{}
+			if err := d.Set("duration", duration); err != nil {
+				return err
+			}
+		} else {
+			if err := d.Set("duration", nil); err != nil {
+				return err
+			}
+		}
+
+		if intWindow.Type == "hourwindow" {
+			if err := d.Set("hours", intWindow.Hours); err != nil {
 				return err
 			}
 		} else {
@@ -209,8 +257,8 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 				return err
 			}
 		}
-		if feature.Window.Type == "daywindow" {
-			if err := d.Set("days", feature.Window.Days); err != nil {
+		if intWindow.Type == "daywindow" {
+			if err := d.Set("days", intWindow.Days); err != nil {
 				return err
 			}
 		} else {
@@ -218,8 +266,8 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 				return err
 			}
 		}
-		if feature.Window.Type == "rowwindow" {
-			if err := d.Set("rows", feature.Window.Rows); err != nil {
+		if intWindow.Type == "rowwindow" {
+			if err := d.Set("rows", intWindow.Rows); err != nil {
 				return err
 			}
 		} else {
@@ -227,8 +275,8 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 				return err
 			}
 		}
-		if feature.Window.Type == "monthwindow" {
-			if err := d.Set("months", feature.Window.Months); err != nil {
+		if intWindow.Type == "monthwindow" {
+			if err := d.Set("months", intWindow.Months); err != nil {
 				return err
 			}
 		} else {
@@ -245,15 +293,11 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 			return err
 		}
 
-		if feature.EntityRestr != nil {
-			if err := d.Set("entity_restrictions", identifierList(*feature.EntityRestr)); err != nil {
-				return err
-			}
-		} else {
-			d.Set("entity_restrictions", nil)
+		if err := setEntityRestrictions(d, c, feature.EntityRestr); err != nil {
+			return err
 		}
 	} else if feature.Type == "row" {
-		if err := d.Set("over", identifierList(feature.Over)); err != nil {
+		if err := d.Set("over", flattenOver(d, feature.Over)); err != nil {
 			return err
 		}
 		if err := d.Set("entity", strconv.Itoa(feature.EntityID)); err != nil {
@@ -263,51 +307,62 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 		return errors.New("Unrecognised ADT type for feature")
 	}
 
-	if err := d.Set("labels", feature.Labels); err != nil {
+	if err := setAuditMetadata(d, feature.AuditMetadata); err != nil {
+		return err
+	}
+	if err := d.Set("deletion_protection", d.Get("deletion_protection").(bool)); err != nil {
+		return err
+	}
+	if err := d.Set("labels", flattenLabels(d, c, feature.Labels)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(feature.Attributes)); err != nil {
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, feature.Attributes))); err != nil {
 		return err
 	}
 	return nil
 }
 
-func resourceFeatureCreate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	feature, err := buildFeature(d)
+	feature, err := buildFeature(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateFeature(*feature)
+	e, warnings, err := c.CreateFeatureBatched(*feature)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceFeatureRead)
 }
 
-func resourceFeatureUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	featureID := d.Id()
-	table, err := buildFeature(d)
+	table, err := buildFeature(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateFeature(featureID, *table)
+	warnings, err := c.UpdateFeature(featureID, *table)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceFeatureRead(d, m)
 }
 
 func resourceFeatureDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	featureID := d.Id()
 
+	if err := checkDeletionProtection(d, "Feature"); err != nil {
+		return err
+	}
+
 	err := c.DeleteFeature(featureID)
 	if err != nil {
 		return err
@@ -316,18 +371,27 @@ func resourceFeatureDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func buildFeature(d *schema.ResourceData) (*Feature, error) {
+func buildFeature(d *schema.ResourceData, c *Client) (*Feature, error) {
+	selectSQL, err := getSQLExpression(d, "select")
+	if err != nil {
+		return nil, err
+	}
+	filterSQL, err := getSQLExpression(d, "filter")
+	if err != nil {
+		return nil, err
+	}
+
 	feature := Feature{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
 		Select: SQLExpression{
-			SQL: d.Get("select").(string),
+			SQL: selectSQL,
 		},
 		Aggregate: &AggregateExpression{
 			Type: d.Get("aggregation").(string),
 		},
-		Labels:     expandLabels(d),
-		Attributes: expandAttributes(d),
+		Labels:     expandLabels(d, c),
+		Attributes: expandAttributes(d, c),
 	}
 
 	if d.Get("template").(string) != "" {
@@ -335,9 +399,9 @@ func buildFeature(d *schema.ResourceData) (*Feature, error) {
 		feature.TemplateID = &template
 	}
 
-	if d.Get("filter").(string) != "" {
+	if filterSQL != "" {
 		feature.Filter = &SQLExpression{
-			SQL: d.Get("filter").(string),
+			SQL: filterSQL,
 		}
 	}
 
@@ -355,7 +419,13 @@ func buildFeature(d *schema.ResourceData) (*Feature, error) {
 		}
 
 		window := EventWindow{}
-		if d.Get("hours").(int) != 0 {
+		if duration := d.Get("duration").(string); duration != "" {
+			parsed, err := parseWindowDuration(duration)
+			if err != nil {
+				return nil, err
+			}
+			window = *parsed
+		} else if d.Get("hours").(int) != 0 {
 			window.Type = "hourwindow"
 			window.Hours = d.Get("hours").(int)
 		} else if d.Get("days").(int) != 0 {
@@ -374,19 +444,245 @@ func buildFeature(d *schema.ResourceData) (*Feature, error) {
 		feature.Type = "event"
 		feature.Table = number
 		feature.Window = &window
-		entity_restrictions := d.Get("entity_restrictions").([]interface{})
-		if len(entity_restrictions) > 0 {
-			listVal := expandIdentifierList(entity_restrictions)
-			feature.EntityRestr = &listVal
-		} else {
-			feature.EntityRestr = nil
+		entityRestrictions, err := expandEntityRestrictions(d, c)
+		if err != nil {
+			return nil, err
 		}
+		feature.EntityRestr = entityRestrictions
 	} else {
 		feature.Type = "row"
-		feature.Over = expandIdentifierList(d.Get("over").([]interface{}))
+		feature.Over = expandIdentifierList(dedupeList(d.Get("over").([]interface{})))
 		number, _ := strconv.Atoi(d.Get("entity").(string))
 		feature.EntityID = number
 	}
 
 	return &feature, nil
 }
+
+// Removes repeated features from a list of features, keeping the order in
+// which they first appear.
+func dedupeList(over []interface{}) []interface{} {
+	seen := make(map[interface{}]bool, len(over))
+	res := make([]interface{}, 0, len(over))
+	for _, v := range over {
+		if !seen[v] {
+			seen[v] = true
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func sameFeatures(a, b []interface{}) bool {
+	a, b = dedupeList(a), dedupeList(b)
+	if len(a) != len(b) {
+		return false
+	}
+	members := make(map[interface{}]bool, len(a))
+	for _, v := range a {
+		members[v] = true
+	}
+	for _, v := range b {
+		if !members[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the features a row feature is over. When the server holds the
+// same features as the configuration, the configuration's order is kept.
+func flattenOver(d *schema.ResourceData, over []int) []interface{} {
+	read := make([]interface{}, 0, len(over))
+	for _, id := range identifierList(over) {
+		read = append(read, id)
+	}
+	read = dedupeList(read)
+
+	configured := dedupeList(d.Get("over").([]interface{}))
+	if sameFeatures(configured, read) {
+		return configured
+	}
+	return read
+}
+
+// Ignores changes to over which only reorder or repeat features.
+func suppressOverReorderDiff(k, old, new string, d *schema.ResourceData) bool {
+	o, n := d.GetChange("over")
+	return sameFeatures(o.([]interface{}), n.([]interface{}))
+}
+
+// The windows each aggregation can be computed over, for aggregations which
+// can't be computed over every window. The change and score aggregations
+// compare the current window to the one before it, so need a window of
+// fixed duration. The basket aggregations keep a running total for each
+// key across all of an entity's events, so can't be given a window.
+var aggregationWindows = map[string][]string{
+	"percentagechange": {"hours", "days", "months"},
+	"absolutechange":   {"hours", "days", "months"},
+	"standardscore":    {"hours", "days", "months"},
+	"basketsum":        {"open"},
+	"basketlast":       {"open"},
+	"basketmax":        {"open"},
+	"basketmin":        {"open"},
+}
+
+// The units a window duration can be written in, with the event window
+// and explicit window field each corresponds to.
+var windowDurationUnits = []struct {
+	suffix string
+	window string
+	field  string
+}{
+	{"h", "hourwindow", "hours"},
+	{"d", "daywindow", "days"},
+	{"mo", "monthwindow", "months"},
+}
+
+var windowDurationPattern = regexp.MustCompile(`^([1-9][0-9]*)(h|d|mo)$`)
+
+// Parses a window duration such as 36h, 90d or 6mo into the event window
+// it describes.
+func parseWindowDuration(duration string) (*EventWindow, error) {
+	match := windowDurationPattern.FindStringSubmatch(duration)
+	if match == nil {
+		return nil, fmt.Errorf("Invalid window duration %q, expected a whole number of hours, days or months such as 36h, 90d or 6mo", duration)
+	}
+	amount, err := strconv.Atoi(match[1])
+	if err != nil {
+		return nil, err
+	}
+
+	switch match[2] {
+	case "h":
+		return &EventWindow{Type: "hourwindow", Hours: amount}, nil
+	case "d":
+		return &EventWindow{Type: "daywindow", Days: amount}, nil
+	default:
+		return &EventWindow{Type: "monthwindow", Months: amount}, nil
+	}
+}
+
+func validateWindowDuration() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		if _, err := parseWindowDuration(i.(string)); err != nil {
+			return nil, []error{err}
+		}
+		return nil, nil
+	}
+}
+
+// Returns the configured window duration if it still describes the window
+// read from the server, or an empty string if the window should be stored
+// in the explicit window fields instead.
+func flattenWindowDuration(d *schema.ResourceData, window *EventWindow) string {
+	duration := d.Get("duration").(string)
+	if duration == "" || window == nil {
+		return ""
+	}
+	parsed, err := parseWindowDuration(duration)
+	if err != nil || *parsed != *window {
+		return ""
+	}
+	return duration
+}
+
+// Returns which window an event feature is computed over, or "open" when
+// it covers all events.
+func featureWindow(d *schema.ResourceDiff) string {
+	if duration := d.Get("duration").(string); duration != "" {
+		if window, err := parseWindowDuration(duration); err == nil {
+			for _, unit := range windowDurationUnits {
+				if unit.window == window.Type {
+					return unit.field
+				}
+			}
+		}
+	}
+	for _, window := range []string{"hours", "days", "months", "rows"} {
+		if d.Get(window).(int) != 0 {
+			return window
+		}
+	}
+	return "open"
+}
+
+func customizeFeatureDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("table").(string) == "" {
+		return nil
+	}
+
+	if c, ok := m.(*Client); ok && c.ValidateFeatureTables && d.NewValueKnown("table") {
+		if err := checkFeatureTable(d, c); err != nil {
+			return err
+		}
+	}
+
+	aggregation := d.Get("aggregation").(string)
+	windows, ok := aggregationWindows[aggregation]
+	if !ok {
+		return nil
+	}
+
+	window := featureWindow(d)
+	for _, allowed := range windows {
+		if window == allowed {
+			return nil
+		}
+	}
+	if len(windows) == 1 && windows[0] == "open" {
+		return fmt.Errorf("Aggregation %s is computed over all events, so can't be given a %s window", aggregation, window)
+	}
+	return fmt.Errorf("Aggregation %s can't be computed over the %s window, set one of: %s", aggregation, window, strings.Join(windows, ", "))
+}
+
+// Features aggregate the events of a root or event store table. Views and
+// pivot tables fail when the feature is run, so this reports them when
+// planning instead. It costs a request per feature, so is only done when
+// the provider sets validate_feature_tables.
+func checkFeatureTable(d *schema.ResourceDiff, c *Client) error {
+	tableID := d.Get("table").(string)
+	table, err := c.GetTable(tableID)
+	if err != nil {
+		return err
+	}
+	if table == nil {
+		return fmt.Errorf("Table %s not found", tableID)
+	}
+
+	if table.Type != "root" && table.Type != "eventstore" {
+		return fmt.Errorf("Table %s is a %s table, features must be built on a root or event store table", table.Name, table.Type)
+	}
+	if window := featureWindow(d); window != "open" && table.EventInfo == nil {
+		return fmt.Errorf("Table %s has no event description, so can't be aggregated over a %s window", table.Name, window)
+	}
+	return nil
+}
+
+// Returns the SQL for an expression which can be given either inline,
+// or as the path to a file in "<key>_file".
+func getSQLExpression(d *schema.ResourceData, key string) (string, error) {
+	if path := d.Get(key + "_file").(string); path != "" {
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(contents)), nil
+	}
+	return d.Get(key).(string), nil
+}
+
+// Sets an SQL expression read from the server. When the expression comes
+// from a file, the file is left as the source of truth; if its contents
+// no longer match the server the path is cleared from state, so the next
+// plan updates the feature from the file.
+func setSQLExpression(d *schema.ResourceData, key string, sql string) error {
+	if path := d.Get(key + "_file").(string); path != "" {
+		contents, err := ioutil.ReadFile(path)
+		if err != nil || strings.TrimSpace(string(contents)) != sql {
+			return d.Set(key+"_file", "")
+		}
+		return nil
+	}
+	return d.Set(key, sql)
+}
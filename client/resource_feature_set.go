@@ -21,32 +21,35 @@ available to be chosen is restricted to Features for that Entity.
 
 func ResourceFeatureSet() *schema.Resource {
 	return &schema.Resource{
-		Description: featureSetDescription,
-		Create:      resourceFeatureSetCreate,
-		Read:        resourceFeatureSetRead,
-		Update:      resourceFeatureSetUpdate,
-		Delete:      resourceFeatureSetDelete,
+		Description:   featureSetDescription,
+		CreateContext: withWarnings(resourceFeatureSetCreate),
+		ReadContext:   withDiagnostics(resourceFeatureSetRead),
+		UpdateContext: withWarnings(resourceFeatureSetUpdate),
+		DeleteContext: withDiagnostics(resourceFeatureSetDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("feature set", (*Client).FindFeatureSetByName),
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: auditSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"entity": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"features": {
 				Type:        schema.TypeSet,
+				Set:         hashIdentifier,
 				Description: "Features to include in the feature set",
 				Required:    true,
 
@@ -67,7 +70,8 @@ func ResourceFeatureSet() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
-		},
+			"deletion_protection": deletionProtectionSchema(),
+		}),
 	}
 }
 
@@ -96,16 +100,22 @@ func resourceFeatureSetRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("features", identifierList(FeatureSet.Features)); err != nil {
 		return err
 	}
-	if err := d.Set("labels", FeatureSet.Labels); err != nil {
+	if err := setAuditMetadata(d, FeatureSet.AuditMetadata); err != nil {
+		return err
+	}
+	if err := d.Set("deletion_protection", d.Get("deletion_protection").(bool)); err != nil {
+		return err
+	}
+	if err := d.Set("labels", flattenLabels(d, c, FeatureSet.Labels)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(FeatureSet.Attributes)); err != nil {
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, FeatureSet.Attributes))); err != nil {
 		return err
 	}
 	return err
 }
 
-func resourceFeatureSetCreate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureSetCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 	entity, _ := strconv.Atoi(d.Get("entity").(string))
 
@@ -114,21 +124,21 @@ func resourceFeatureSetCreate(d *schema.ResourceData, m interface{}) error {
 		Description: d.Get("description").(string),
 		EntityID:    entity,
 		Features:    expandIdentifierList(d.Get("features").(*schema.Set).List()),
-		Labels:      expandLabels(d),
-		Attributes:  expandAttributes(d),
+		Labels:      expandLabels(d, c),
+		Attributes:  expandAttributes(d, c),
 	}
 
-	e, err := c.CreateFeatureSet(FeatureSet)
+	e, warnings, err := c.CreateFeatureSet(FeatureSet)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceFeatureSetRead)
 }
 
-func resourceFeatureSetUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureSetUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	entity, _ := strconv.Atoi(d.Get("entity").(string))
 	FeatureSetID := d.Id()
 
@@ -137,22 +147,27 @@ func resourceFeatureSetUpdate(d *schema.ResourceData, m interface{}) error {
 		Description: d.Get("description").(string),
 		EntityID:    entity,
 		Features:    expandIdentifierList(d.Get("features").(*schema.Set).List()),
-		Labels:      expandLabels(d),
-		Attributes:  expandAttributes(d),
+		Labels:      expandLabels(d, c),
+		Attributes:  expandAttributes(d, c),
 	}
 
-	err := c.UpdateFeatureSet(FeatureSetID, FeatureSet)
+	warnings, err := c.UpdateFeatureSet(FeatureSetID, FeatureSet)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceFeatureSetRead(d, m)
 }
 
 func resourceFeatureSetDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	FeatureSetID := d.Id()
 
+	if err := checkDeletionProtection(d, "Feature set"); err != nil {
+		return err
+	}
+
 	err := c.DeleteFeatureSet(FeatureSetID)
 	if err != nil {
 		return err
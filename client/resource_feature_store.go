@@ -1,10 +1,14 @@
 package anaml
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const featureStoreDescription = `
@@ -22,24 +26,26 @@ An entity population can be used to further refine the entities and dates for fe
 
 func ResourceFeatureStore() *schema.Resource {
 	return &schema.Resource{
-		Description: featureStoreDescription,
-		Create:      resourceFeatureStoreCreate,
-		Read:        resourceFeatureStoreRead,
-		Update:      resourceFeatureStoreUpdate,
-		Delete:      resourceFeatureStoreDelete,
+		Description:   featureStoreDescription,
+		CreateContext: withWarnings(resourceFeatureStoreCreate),
+		ReadContext:   withDiagnostics(resourceFeatureStoreRead),
+		UpdateContext: withWarnings(resourceFeatureStoreUpdate),
+		DeleteContext: withDiagnostics(resourceFeatureStoreDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("feature store", (*Client).FindFeatureStoreByName),
 		},
+		CustomizeDiff: customizeFeatureStoreDiff,
 
-		Schema: map[string]*schema.Schema{
+		Schema: auditSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"run_date_offset": {
 				Type:     schema.TypeInt,
@@ -59,25 +65,49 @@ func ResourceFeatureStore() *schema.Resource {
 				ConflictsWith: []string{"run_date_offset", "start_date", "end_date"},
 			},
 			"feature_set": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
+			},
+			"validate_feature_set": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Check during planning that the referenced feature set exists.",
 			},
 			"principal": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Description:  "The user or group the job runs as, given as an ID, user:name or group:name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"owner": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The name or ID of the user who owns the feature store.",
+				ValidateFunc: validateOwner(),
 			},
 			"enabled": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  true,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the feature store's schedule runs. Setting this to false pauses the schedule in place, without replacing the feature store.",
 			},
 			"include_metadata": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
 			},
+			"metadata_columns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The metadata columns to write when include_metadata is set, named as the server names them. Unset writes all of them",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
 			"daily_schedule": {
 				Type:          schema.TypeList,
 				Optional:      true,
@@ -97,17 +127,26 @@ func ResourceFeatureStore() *schema.Resource {
 				Optional: true,
 				Elem:     destinationSchema(),
 			},
+			"merge_destinations": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to keep destinations added to the feature store outside of this resource. When set, only the destinations declared here are managed, matched by destination ID, and any others are left in place",
+			},
 			"cluster": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The ID of the cluster to run on. Defaults to the provider's default_cluster",
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"cluster_property_sets": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 			"additional_spark_properties": {
@@ -121,9 +160,10 @@ func ResourceFeatureStore() *schema.Resource {
 				},
 			},
 			"entity_population": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"labels": {
 				Type:        schema.TypeSet,
@@ -148,10 +188,59 @@ func ResourceFeatureStore() *schema.Resource {
 				Description:   "Branch to run feature set (and population) for.",
 				ConflictsWith: []string{"commit_target"},
 			},
-		},
+			"run_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Any value. Setting or changing it starts a run of the feature store once it has been created or updated. The value is only kept in state and is never sent to the server",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the apply waits for a run started by run_trigger to finish, and fails if the run fails. This extends the apply by as long as the run takes, which can be significant. A failed run when the feature store is first created taints it",
+			},
+			"completion_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultCompletionTimeout,
+				Description:  "How long wait_for_completion waits for the run, such as 30m or 2h. The apply fails once it has passed, though the run carries on",
+				ValidateFunc: ValidateDuration(),
+			},
+			"deletion_protection": deletionProtectionSchema(),
+		}),
 	}
 }
 
+// How long wait_for_completion waits when completion_timeout isn't set.
+const defaultCompletionTimeout = "1h"
+
+func customizeFeatureStoreDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	c := m.(*Client)
+
+	if columns, _ := d.Get("metadata_columns").([]interface{}); len(columns) > 0 && !d.Get("include_metadata").(bool) {
+		return errors.New("metadata_columns can only be set when include_metadata is true")
+	}
+
+	oldDestinations, newDestinations := d.GetChange("destination")
+	if err := checkDestinationOptionKeys("destination", oldDestinations.([]interface{}), newDestinations.([]interface{})); err != nil {
+		return err
+	}
+
+	if !d.Get("validate_feature_set").(bool) || !d.NewValueKnown("feature_set") {
+		return nil
+	}
+
+	featureSetID := d.Get("feature_set").(string)
+	featureSet, err := c.GetFeatureSet(featureSetID)
+	if err != nil {
+		return err
+	}
+	if featureSet == nil {
+		return fmt.Errorf("Feature set %s referenced by feature_set does not exist", featureSetID)
+	}
+	return nil
+}
+
 func resourceFeatureStoreRead(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	FeatureStoreID := d.Id()
@@ -172,39 +261,76 @@ func resourceFeatureStoreRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	// Fields which don't apply to this kind of feature store are cleared,
+	// so that an imported feature store reads back exactly as configured.
+	var startDate, endDate *string
+	var runDateOffset, table *int
 	if FeatureStore.Type == "batch" {
-		if FeatureStore.StartDate != nil {
-			if err := d.Set("start_date", *FeatureStore.StartDate); err != nil {
-				return err
-			}
-		}
-		if FeatureStore.RunDateOffset != nil {
-			if err := d.Set("run_date_offset", *FeatureStore.RunDateOffset); err != nil {
-				return err
-			}
-		}
-		if FeatureStore.EndDate != nil {
-			if err := d.Set("end_date", *FeatureStore.EndDate); err != nil {
-				return err
-			}
-		}
+		startDate = FeatureStore.StartDate
+		endDate = FeatureStore.EndDate
+		runDateOffset = FeatureStore.RunDateOffset
 	}
 	if FeatureStore.Type == "streaming" {
 		if FeatureStore.Table == nil {
 			return errors.New("Required field is missing for streaming feature store: table")
 		}
-		if err := d.Set("table", *FeatureStore.Table); err != nil {
+		table = FeatureStore.Table
+	}
+	if err := d.Set("start_date", startDate); err != nil {
+		return err
+	}
+	if err := d.Set("end_date", endDate); err != nil {
+		return err
+	}
+	if err := d.Set("run_date_offset", runDateOffset); err != nil {
+		return err
+	}
+	if err := d.Set("table", table); err != nil {
+		return err
+	}
+
+	if FeatureStore.Principal != nil {
+		principal, err := flattenPrincipal(d, c, "principal", *FeatureStore.Principal)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("principal", principal); err != nil {
+			return err
+		}
+	} else {
+		if err := d.Set("principal", nil); err != nil {
 			return err
 		}
 	}
 
-	if FeatureStore.Principal != nil {
-		if err := d.Set("principal", strconv.Itoa(*FeatureStore.Principal)); err != nil {
+	if FeatureStore.Owner != nil {
+		owner, err := flattenOwner(d, c, *FeatureStore.Owner)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("owner", owner); err != nil {
+			return err
+		}
+	} else {
+		if err := d.Set("owner", nil); err != nil {
 			return err
 		}
 	}
 
-	destinations, err := flattenDestinationReferences(FeatureStore.Destinations)
+	managedDestinations := FeatureStore.Destinations
+	if d.Get("merge_destinations").(bool) {
+		managed, err := destinationIDs(c, d.Get("destination").([]interface{}))
+		if err != nil {
+			return err
+		}
+		managedDestinations = make([]DestinationReference, 0, len(FeatureStore.Destinations))
+		for _, destination := range FeatureStore.Destinations {
+			if managed[destination.DestinationID] {
+				managedDestinations = append(managedDestinations, destination)
+			}
+		}
+	}
+	destinations, err := flattenDestinationReferences(c, managedDestinations, d.Get("destination").([]interface{}))
 	if err != nil {
 		return err
 	}
@@ -218,10 +344,17 @@ func resourceFeatureStoreRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("include_metadata", FeatureStore.IncludeMetadata); err != nil {
 		return err
 	}
+	if err := d.Set("metadata_columns", FeatureStore.MetadataColumns); err != nil {
+		return err
+	}
 	if err := d.Set("destination", destinations); err != nil {
 		return err
 	}
-	if err := d.Set("cluster", strconv.Itoa(FeatureStore.Cluster)); err != nil {
+	cluster, err := flattenCluster(d, c, FeatureStore.Cluster)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("cluster", cluster); err != nil {
 		return err
 	}
 	if err := d.Set("cluster_property_sets", identifierList(FeatureStore.ClusterPropertySets)); err != nil {
@@ -230,37 +363,69 @@ func resourceFeatureStoreRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("additional_spark_properties", FeatureStore.AdditionalSparkProperties); err != nil {
 		return err
 	}
-	if err := d.Set("labels", FeatureStore.Labels); err != nil {
+	if err := setAuditMetadata(d, FeatureStore.AuditMetadata); err != nil {
+		return err
+	}
+	if err := d.Set("deletion_protection", d.Get("deletion_protection").(bool)); err != nil {
+		return err
+	}
+	if err := d.Set("validate_feature_set", d.Get("validate_feature_set").(bool)); err != nil {
+		return err
+	}
+	if err := d.Set("merge_destinations", d.Get("merge_destinations").(bool)); err != nil {
+		return err
+	}
+	if err := d.Set("run_trigger", d.Get("run_trigger").(string)); err != nil {
+		return err
+	}
+	if err := d.Set("wait_for_completion", d.Get("wait_for_completion").(bool)); err != nil {
+		return err
+	}
+	// An imported feature store has no completion_timeout in state, so
+	// use the default rather than planning a change to it.
+	completionTimeout := d.Get("completion_timeout").(string)
+	if completionTimeout == "" {
+		completionTimeout = defaultCompletionTimeout
+	}
+	if err := d.Set("completion_timeout", completionTimeout); err != nil {
+		return err
+	}
+	if err := d.Set("labels", flattenLabels(d, c, FeatureStore.Labels)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(FeatureStore.Attributes)); err != nil {
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, FeatureStore.Attributes))); err != nil {
 		return err
 	}
 	if FeatureStore.Population != nil {
 		if err := d.Set("entity_population", strconv.Itoa(*FeatureStore.Population)); err != nil {
 			return err
 		}
-	}
-
-	if FeatureStore.Schedule.Type == "daily" {
-		dailySchedules, err := parseDailySchedule(FeatureStore.Schedule)
-		if err != nil {
-			return err
-		}
-		if err := d.Set("daily_schedule", dailySchedules); err != nil {
+	} else {
+		if err := d.Set("entity_population", nil); err != nil {
 			return err
 		}
 	}
 
-	if FeatureStore.Schedule.Type == "cron" {
-		cronSchedules, err := parseCronSchedule(FeatureStore.Schedule)
+	dailySchedules := []map[string]interface{}{}
+	cronSchedules := []map[string]interface{}{}
+	if FeatureStore.Schedule != nil && FeatureStore.Schedule.Type == "daily" {
+		dailySchedules, err = parseDailySchedule(FeatureStore.Schedule)
 		if err != nil {
 			return err
 		}
-		if err := d.Set("cron_schedule", cronSchedules); err != nil {
+	}
+	if FeatureStore.Schedule != nil && FeatureStore.Schedule.Type == "cron" {
+		cronSchedules, err = parseCronSchedule(FeatureStore.Schedule)
+		if err != nil {
 			return err
 		}
 	}
+	if err := d.Set("daily_schedule", dailySchedules); err != nil {
+		return err
+	}
+	if err := d.Set("cron_schedule", cronSchedules); err != nil {
+		return err
+	}
 
 	if FeatureStore.VersionTarget != nil {
 		if FeatureStore.VersionTarget.Commit != nil {
@@ -290,55 +455,163 @@ func resourceFeatureStoreRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceFeatureStoreCreate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureStoreCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	FeatureStore, err := composeFeatureStore(d)
+	FeatureStore, err := composeFeatureStore(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateFeatureStore(*FeatureStore)
+	e, warnings, err := c.CreateFeatureStore(*FeatureStore)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	if err := readAfterCreate(d, m, strconv.Itoa(e.ID), resourceFeatureStoreRead); err != nil {
+		return warnings, err
+	}
+
+	if d.Get("run_trigger").(string) == "" {
+		return warnings, nil
+	}
+	runWarnings, err := runFeatureStore(d, c)
+	return append(warnings, runWarnings...), err
 }
 
-func resourceFeatureStoreUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureStoreUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	FeatureStoreID := d.Id()
-	FeatureStore, err := composeFeatureStore(d)
+	FeatureStore, err := composeFeatureStore(d, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Get("merge_destinations").(bool) {
+		if err := mergeFeatureStoreDestinations(d, c, FeatureStore); err != nil {
+			return nil, err
+		}
+	}
+
+	warnings, err := c.UpdateFeatureStore(FeatureStoreID, *FeatureStore)
+	if err != nil {
+		return warnings, err
+	}
+
+	if err := resourceFeatureStoreRead(d, m); err != nil {
+		return warnings, err
+	}
+
+	// The state is kept partial until the run has started, so that an
+	// apply which fails to run keeps the old trigger and runs again.
+	if d.HasChange("run_trigger") && d.Get("run_trigger").(string) != "" {
+		runWarnings, err := runFeatureStore(d, c)
+		warnings = append(warnings, runWarnings...)
+		if err != nil {
+			return warnings, err
+		}
+	}
+
+	d.Partial(false)
+	return warnings, nil
+}
+
+// The delay before first checking on a run started by run_trigger, which
+// then grows between checks.
+var featureStoreRunPollDelay = 5 * time.Second
+
+// Starts a run of the feature store and, when wait_for_completion is set,
+// polls it until it has either completed or failed.
+func runFeatureStore(d *schema.ResourceData, c *Client) ([]string, error) {
+	run, warnings, err := c.RunFeatureStore(d.Id())
 	if err != nil {
+		return warnings, err
+	}
+	if !d.Get("wait_for_completion").(bool) {
+		return warnings, nil
+	}
+
+	timeout, err := time.ParseDuration(d.Get("completion_timeout").(string))
+	if err != nil {
+		return warnings, err
+	}
+
+	runID := strconv.Itoa(run.ID)
+	err = poll(featureStoreRunPollDelay, timeout, func() (bool, error) {
+		run, err := c.GetFeatureStoreRun(d.Id(), runID)
+		if err != nil {
+			return false, err
+		}
+		if run == nil {
+			return false, fmt.Errorf("Run %s of feature store %s no longer exists", runID, d.Id())
+		}
+		switch run.Status {
+		case "completed":
+			return true, nil
+		case "failed":
+			if run.Error != nil {
+				return false, fmt.Errorf("Run %s of feature store %s failed: %s", runID, d.Id(), *run.Error)
+			}
+			return false, fmt.Errorf("Run %s of feature store %s failed", runID, d.Id())
+		}
+		return false, nil
+	})
+	if err == errPollTimeout {
+		return warnings, fmt.Errorf("Run %s of feature store %s didn't finish within %s", runID, d.Id(), d.Get("completion_timeout").(string))
+	}
+	return warnings, err
+}
+
+// Keeps the destinations on the server which this resource doesn't
+// manage, ahead of the declared ones. A destination is managed when it
+// is declared now or was declared before, so removing one from the
+// configuration still removes it from the feature store.
+func mergeFeatureStoreDestinations(d *schema.ResourceData, c *Client, featureStore *FeatureStore) error {
+	existing, err := c.GetFeatureStore(d.Id())
+	if err != nil || existing == nil {
 		return err
 	}
 
-	err = c.UpdateFeatureStore(FeatureStoreID, *FeatureStore)
+	old, _ := d.GetChange("destination")
+	managed, err := destinationIDs(c, old.([]interface{}))
 	if err != nil {
 		return err
 	}
+	for _, destination := range featureStore.Destinations {
+		managed[destination.DestinationID] = true
+	}
 
+	merged := make([]DestinationReference, 0, len(existing.Destinations)+len(featureStore.Destinations))
+	for _, destination := range existing.Destinations {
+		if !managed[destination.DestinationID] {
+			merged = append(merged, destination)
+		}
+	}
+	featureStore.Destinations = append(merged, featureStore.Destinations...)
 	return nil
 }
 
-func composeFeatureStore(d *schema.ResourceData) (*FeatureStore, error) {
+func composeFeatureStore(d *schema.ResourceData, c *Client) (*FeatureStore, error) {
 	featureSet, err := strconv.Atoi(d.Get("feature_set").(string))
 	if err != nil {
 		return nil, err
 	}
 
-	var principal (*int) = nil
-	principalRaw, principalOk := d.GetOk("principal")
-	if principalOk {
-		principal_, err := strconv.Atoi(principalRaw.(string))
+	principal, err := resolvePrincipal(c, d, "principal")
+	if err != nil {
+		return nil, err
+	}
+
+	var owner (*int) = nil
+	if ownerRaw, ownerOk := d.GetOk("owner"); ownerOk {
+		owner_, err := expandOwner(c, ownerRaw.(string))
 		if err != nil {
 			return nil, err
 		}
-		principal = &principal_
+		owner = &owner_
 	}
 
-	cluster, err := strconv.Atoi(d.Get("cluster").(string))
+	cluster, err := expandCluster(d, c)
 	if err != nil {
 		return nil, err
 	}
@@ -386,7 +659,7 @@ func composeFeatureStore(d *schema.ResourceData) (*FeatureStore, error) {
 		}
 	}
 
-	destinations, err := expandDestinationReferences(d.Get("destination").([]interface{}))
+	destinations, err := expandDestinationReferences(c, d, "destination")
 	if err != nil {
 		return nil, err
 	}
@@ -396,6 +669,7 @@ func composeFeatureStore(d *schema.ResourceData) (*FeatureStore, error) {
 		Description:               d.Get("description").(string),
 		FeatureSet:                featureSet,
 		Principal:                 principal,
+		Owner:                     owner,
 		Enabled:                   d.Get("enabled").(bool),
 		Destinations:              destinations,
 		Cluster:                   cluster,
@@ -403,12 +677,18 @@ func composeFeatureStore(d *schema.ResourceData) (*FeatureStore, error) {
 		AdditionalSparkProperties: additionalSparkProperties,
 		Population:                population,
 		Schedule:                  schedule,
-		Labels:                    expandLabels(d),
-		Attributes:                expandAttributes(d),
+		Labels:                    expandLabels(d, c),
+		Attributes:                expandAttributes(d, c),
 		IncludeMetadata:           d.Get("include_metadata").(bool),
 		VersionTarget:             versionTarget,
 	}
 
+	if featureStore.IncludeMetadata {
+		if columns := expandStringList(d.Get("metadata_columns").([]interface{})); len(columns) > 0 {
+			featureStore.MetadataColumns = columns
+		}
+	}
+
 	table := getNullableInt(d, "table")
 	if table != nil {
 		featureStore.Type = "streaming"
@@ -427,6 +707,10 @@ func resourceFeatureStoreDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	FeatureStoreID := d.Id()
 
+	if err := checkDeletionProtection(d, "Feature store"); err != nil {
+		return err
+	}
+
 	err := c.DeleteFeatureStore(FeatureStoreID)
 	if err != nil {
 		return err
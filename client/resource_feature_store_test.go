@@ -0,0 +1,387 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestRunFeatureStore(t *testing.T) {
+	defer func(delay time.Duration) { featureStoreRunPollDelay = delay }(featureStoreRunPollDelay)
+	featureStoreRunPollDelay = time.Millisecond
+
+	cases := []struct {
+		name     string
+		wait     bool
+		timeout  string
+		statuses []string
+		wantErr  string
+		wantGets int32
+	}{
+		{"without waiting", false, "1h", nil, "", 0},
+		{"completed", true, "1h", []string{"pending", "running", "completed"}, "", 3},
+		{"failed", true, "1h", []string{"running", "failed"}, "Run 7 of feature store 3 failed: out of memory", 2},
+		{"timed out", true, "20ms", []string{"running"}, "didn't finish within 20ms", -1},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var gets int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/feature-store/3/run":
+					w.Write([]byte(`7`))
+				case r.Method == "GET" && r.URL.Path == "/feature-store/3/run/7":
+					n := int(atomic.AddInt32(&gets, 1))
+					status := tt.statuses[len(tt.statuses)-1]
+					if n <= len(tt.statuses) {
+						status = tt.statuses[n-1]
+					}
+					w.Write([]byte(`{"id": 7, "featureStoreId": 3, "status": "` + status + `", "error": "out of memory"}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, ResourceFeatureStore().Schema, map[string]interface{}{
+				"name":                "daily",
+				"feature_set":         "1",
+				"run_trigger":         "1",
+				"wait_for_completion": tt.wait,
+				"completion_timeout":  tt.timeout,
+			})
+			d.SetId("3")
+
+			_, err := runFeatureStore(d, c)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("err = %v, want none", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantGets >= 0 && gets != tt.wantGets {
+				t.Errorf("polled %d times, want %d", gets, tt.wantGets)
+			}
+		})
+	}
+}
+
+func TestMergeFeatureStoreDestinations(t *testing.T) {
+	folders := func(ids ...string) []interface{} {
+		res := make([]interface{}, 0, len(ids))
+		for _, id := range ids {
+			res = append(res, map[string]interface{}{
+				"destination": id,
+				"folder":      []interface{}{map[string]interface{}{"path": "/out", "save_mode": "overwrite"}},
+			})
+		}
+		return res
+	}
+
+	cases := []struct {
+		name     string
+		merge    bool
+		old, new []interface{}
+		server   []int
+		wantSent []int
+	}{
+		{"keeps unmanaged destinations", true, folders("11"), folders("11", "12"), []int{10, 11}, []int{10, 11, 12}},
+		{"removes destinations no longer declared", true, folders("11", "12"), folders("11"), []int{10, 11, 12}, []int{10, 11}},
+		{"replaces destinations without merging", false, folders("11"), folders("11", "12"), []int{10, 11}, []int{11, 12}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := FeatureStore{ID: 3, Type: "batch", Name: "daily", FeatureSet: 1, Cluster: 1}
+			for _, id := range tt.server {
+				stored.Destinations = append(stored.Destinations, DestinationReference{Type: "folder", DestinationID: id, Folder: "/out", Mode: "overwrite"})
+			}
+
+			var sent []int
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/feature-store/3":
+					json.NewEncoder(w).Encode(stored)
+				case r.Method == "PUT" && r.URL.Path == "/feature-store/3":
+					if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+						t.Error(err)
+					}
+					stored.ID = 3
+					for _, destination := range stored.Destinations {
+						sent = append(sent, destination.DestinationID)
+					}
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			config := func(destinations []interface{}) map[string]interface{} {
+				return map[string]interface{}{
+					"name":               "daily",
+					"feature_set":        "1",
+					"cluster":            "1",
+					"merge_destinations": tt.merge,
+					"destination":        destinations,
+				}
+			}
+			d := schema.TestResourceDataRaw(t, ResourceFeatureStore().Schema, config(tt.old))
+			d.SetId("3")
+
+			resource := ResourceFeatureStore()
+			diff, err := resource.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config(tt.new)), c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state, diags := resource.Apply(context.Background(), d.State(), diff, c)
+			if diags.HasError() {
+				t.Fatalf("diags = %v", diags)
+			}
+
+			if !reflect.DeepEqual(sent, tt.wantSent) {
+				t.Errorf("sent destinations %v, want %v", sent, tt.wantSent)
+			}
+			if got, want := state.Attributes["destination.#"], strconv.Itoa(len(tt.new)); got != want {
+				t.Errorf("%s destinations in state, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestFeatureStoreImportPlansCleanly(t *testing.T) {
+	cases := []struct {
+		name   string
+		server string
+		config map[string]interface{}
+	}{
+		{
+			"batch with a daily schedule and no retry policy",
+			`{"id": 3, "adt_type": "batch", "name": "daily", "description": "", "featureSet": 1, "enabled": true,
+			  "schedule": {"adt_type": "daily", "startTimeOfDay": "02:00:00"}, "destinations": [], "cluster": 1,
+			  "clusterPropertySets": [], "startDate": "2024-01-01", "runDateOffset": 1, "includeMetadata": true,
+			  "labels": [], "attributes": []}`,
+			map[string]interface{}{
+				"name":            "daily",
+				"feature_set":     "1",
+				"cluster":         "1",
+				"start_date":      "2024-01-01",
+				"run_date_offset": 1,
+				"daily_schedule":  []interface{}{map[string]interface{}{"start_time_of_day": "02:00:00"}},
+			},
+		},
+		{
+			"streaming with a cron schedule",
+			`{"id": 3, "adt_type": "streaming", "name": "stream", "description": "", "featureSet": 1, "enabled": false,
+			  "schedule": {"adt_type": "cron", "cronString": "0 * * * *"}, "destinations": [], "cluster": 1,
+			  "clusterPropertySets": [], "table": 9, "includeMetadata": false, "labels": [], "attributes": []}`,
+			map[string]interface{}{
+				"name":             "stream",
+				"feature_set":      "1",
+				"cluster":          "1",
+				"table":            9,
+				"enabled":          false,
+				"include_metadata": false,
+				"cron_schedule":    []interface{}{map[string]interface{}{"cron_string": "0 * * * *"}},
+			},
+		},
+		{
+			"destinations with options given as blocks",
+			`{"id": 3, "adt_type": "batch", "name": "daily", "description": "", "featureSet": 1, "enabled": true,
+			  "schedule": {"adt_type": "never"}, "cluster": 1, "clusterPropertySets": [], "includeMetadata": true,
+			  "labels": [], "attributes": [], "versionTarget": {"adt_type": "commit", "commitId": "abc123"},
+			  "destinations": [
+			    {"adt_type": "folder", "destinationId": 11, "folder": "/out", "saveMode": "overwrite",
+			     "options": [{"key": "compression", "value": "gzip"}]},
+			    {"adt_type": "table", "destinationId": 12, "tableName": "features", "saveMode": "append",
+			     "options": [{"key": "mergeSchema", "value": "true"}, {"key": "path", "value": "/tables"}]},
+			    {"adt_type": "topic", "destinationId": 13, "topic": "features", "format": {"adt_type": "avro"},
+			     "options": [{"key": "acks", "value": "all"}]}
+			  ]}`,
+			map[string]interface{}{
+				"name":          "daily",
+				"feature_set":   "1",
+				"cluster":       "1",
+				"commit_target": "abc123",
+				"destination": []interface{}{
+					map[string]interface{}{
+						"destination": "11",
+						"folder":      []interface{}{map[string]interface{}{"path": "/out", "save_mode": "overwrite"}},
+						"option":      []interface{}{map[string]interface{}{"key": "compression", "value": "gzip"}},
+					},
+					map[string]interface{}{
+						"destination": "12",
+						"table":       []interface{}{map[string]interface{}{"name": "features", "save_mode": "append"}},
+						"option":      []interface{}{map[string]interface{}{"key": "mergeSchema", "value": "true"}},
+						"options":     map[string]interface{}{"path": "/tables"},
+					},
+					map[string]interface{}{
+						"destination": "13",
+						"topic":       []interface{}{map[string]interface{}{"name": "features", "format": "avro"}},
+						"option":      []interface{}{map[string]interface{}{"key": "acks", "value": "all"}},
+					},
+				},
+			},
+		},
+		{
+			"destinations with options given as a map",
+			`{"id": 3, "adt_type": "batch", "name": "daily", "description": "", "featureSet": 1, "enabled": true,
+			  "schedule": {"adt_type": "never"}, "cluster": 1, "clusterPropertySets": [], "includeMetadata": true,
+			  "labels": [], "attributes": [], "versionTarget": {"adt_type": "branch", "branchName": "main"},
+			  "destinations": [
+			    {"adt_type": "folder", "destinationId": 11, "folder": "/out", "saveMode": "overwrite",
+			     "options": [{"key": "compression", "value": "gzip"}]}
+			  ]}`,
+			map[string]interface{}{
+				"name":          "daily",
+				"feature_set":   "1",
+				"cluster":       "1",
+				"branch_target": "main",
+				"destination": []interface{}{
+					map[string]interface{}{
+						"destination": "11",
+						"folder":      []interface{}{map[string]interface{}{"path": "/out", "save_mode": "overwrite"}},
+						"options":     map[string]interface{}{"compression": "gzip"},
+					},
+				},
+			},
+		},
+		{
+			"batch without a schedule",
+			`{"id": 3, "adt_type": "batch", "name": "once", "description": "", "featureSet": 1, "enabled": true,
+			  "schedule": {"adt_type": "never"}, "destinations": [], "cluster": 1, "clusterPropertySets": [],
+			  "includeMetadata": true, "labels": [], "attributes": []}`,
+			map[string]interface{}{
+				"name":        "once",
+				"feature_set": "1",
+				"cluster":     "1",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "GET" || r.URL.Path != "/feature-store/3" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte(tt.server))
+			})
+
+			resource := ResourceFeatureStore()
+			d := resource.Data(nil)
+			d.SetId("3")
+			if diags := resource.ReadContext(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("diags = %v", diags)
+			}
+
+			diff, err := resource.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(tt.config), c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !diff.Empty() {
+				t.Errorf("diff after import = %v, want none", diff)
+			}
+		})
+	}
+}
+
+func TestFeatureStoreDisablesInPlace(t *testing.T) {
+	stored := `{"id": 3, "adt_type": "batch", "name": "daily", "description": "", "featureSet": 1, "enabled": %t,
+	  "schedule": {"adt_type": "never"}, "destinations": [], "cluster": 1, "clusterPropertySets": [],
+	  "includeMetadata": true, "labels": [], "attributes": []}`
+	enabled := true
+	var puts int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/feature-store/3":
+			w.Write([]byte(fmt.Sprintf(stored, enabled)))
+		case r.Method == "PUT" && r.URL.Path == "/feature-store/3":
+			atomic.AddInt32(&puts, 1)
+			sent := FeatureStore{}
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Error(err)
+			}
+			enabled = sent.Enabled
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	resource := ResourceFeatureStore()
+	d := resource.Data(nil)
+	d.SetId("3")
+	if diags := resource.ReadContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("diags = %v", diags)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "daily",
+		"feature_set": "1",
+		"cluster":     "1",
+		"enabled":     false,
+	})
+	diff, err := resource.Diff(context.Background(), d.State(), config, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RequiresNew() {
+		t.Errorf("diff = %v, want an in-place update", diff)
+	}
+	if attr, ok := diff.Attributes["enabled"]; !ok || attr.Old != "true" || attr.New != "false" {
+		t.Errorf("diff = %v, want enabled to change from true to false", diff)
+	}
+
+	state, diags := resource.Apply(context.Background(), d.State(), diff, c)
+	if diags.HasError() {
+		t.Fatalf("diags = %v", diags)
+	}
+	if puts != 1 || enabled {
+		t.Errorf("sent %d updates leaving enabled %v, want one setting it to false", puts, enabled)
+	}
+	if state.ID != "3" || state.Attributes["enabled"] != "false" {
+		t.Errorf("state = %v, want feature store 3 disabled", state)
+	}
+}
+
+func TestFeatureStoreMetadataColumns(t *testing.T) {
+	cases := []struct {
+		name    string
+		columns []interface{}
+		wantErr bool
+	}{
+		{"unset", nil, false},
+		{"named by the server", []interface{}{"anaml_run_id", "anaml_computed_at"}, false},
+		{"blank", []interface{}{" "}, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"name":        "daily",
+				"feature_set": "1",
+				"cluster":     "1",
+			}
+			if tt.columns != nil {
+				config["metadata_columns"] = tt.columns
+			}
+			diags := ResourceFeatureStore().Validate(terraform.NewResourceConfigRaw(config))
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("errors = %v, want error %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
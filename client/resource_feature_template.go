@@ -23,30 +23,32 @@ then that field will not be changed when the Feature Template is updated.
 // ResourceFeatureTemplate ...
 func ResourceFeatureTemplate() *schema.Resource {
 	return &schema.Resource{
-		Description: featureTemplateDescription,
-		Create:      resourceFeatureTemplateCreate,
-		Read:        resourceFeatureTemplateRead,
-		Update:      resourceFeatureTemplateUpdate,
-		Delete:      resourceFeatureTemplateDelete,
+		Description:   featureTemplateDescription,
+		CreateContext: withWarnings(resourceFeatureTemplateCreate),
+		ReadContext:   withDiagnostics(resourceFeatureTemplateRead),
+		UpdateContext: withWarnings(resourceFeatureTemplateUpdate),
+		DeleteContext: withDiagnostics(resourceFeatureTemplateDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("feature template", (*Client).FindFeatureTemplateByName),
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: auditSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"table": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "A reference to a Table ID the feature is derived from",
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "A reference to a Table ID the feature is derived from",
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"select": {
 				Type:        schema.TypeString,
@@ -101,13 +103,15 @@ func ResourceFeatureTemplate() *schema.Resource {
 				Description: "An SQL expression to apply to the result of the feature aggregation.",
 			},
 			"entity_restrictions": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "List of entity Id's that the feature is restricted to.",
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "List of entity Id's that the feature is restricted to.",
+				ConflictsWith: []string{"entity_restriction_labels"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"entity_restriction_labels": entityRestrictionLabelsSchema(),
 			"over": {
 				Type:         schema.TypeList,
 				Optional:     true,
@@ -115,15 +119,17 @@ func ResourceFeatureTemplate() *schema.Resource {
 				AtLeastOneOf: []string{"table", "over"},
 
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 			"entity": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ValidateFunc: validateAnamlIdentifier(),
-				RequiredWith: []string{"over"},
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
+				RequiredWith:     []string{"over"},
 			},
 			"labels": {
 				Type:        schema.TypeSet,
@@ -137,7 +143,8 @@ func ResourceFeatureTemplate() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
-		},
+			"deletion_protection": deletionProtectionSchema(),
+		}),
 	}
 }
 
@@ -225,12 +232,8 @@ func resourceFeatureTemplateRead(d *schema.ResourceData, m interface{}) error {
 			return err
 		}
 
-		if feature.EntityRestr != nil {
-			if err := d.Set("entity_restrictions", identifierList(*feature.EntityRestr)); err != nil {
-				return err
-			}
-		} else {
-			d.Set("entity_restrictions", nil)
+		if err := setEntityRestrictions(d, c, feature.EntityRestr); err != nil {
+			return err
 		}
 
 	} else if feature.Type == "row" {
@@ -244,52 +247,63 @@ func resourceFeatureTemplateRead(d *schema.ResourceData, m interface{}) error {
 		return errors.New("Unrecognised ADT type for feature")
 	}
 
-	if err := d.Set("labels", feature.Labels); err != nil {
+	if err := setAuditMetadata(d, feature.AuditMetadata); err != nil {
+		return err
+	}
+	if err := d.Set("deletion_protection", d.Get("deletion_protection").(bool)); err != nil {
+		return err
+	}
+	if err := d.Set("labels", flattenLabels(d, c, feature.Labels)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(feature.Attributes)); err != nil {
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, feature.Attributes))); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func resourceFeatureTemplateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureTemplateCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	template, err := buildFeatureTemplate(d)
+	template, err := buildFeatureTemplate(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateFeatureTemplate(*template)
+	e, warnings, err := c.CreateFeatureTemplate(*template)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceFeatureTemplateRead)
 }
 
-func resourceFeatureTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureTemplateUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	templateID := d.Id()
-	template, err := buildFeatureTemplate(d)
+	template, err := buildFeatureTemplate(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateFeatureTemplate(templateID, *template)
+	warnings, err := c.UpdateFeatureTemplate(templateID, *template)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceFeatureTemplateRead(d, m)
 }
 
 func resourceFeatureTemplateDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	templateID := d.Id()
 
+	if err := checkDeletionProtection(d, "Feature template"); err != nil {
+		return err
+	}
+
 	err := c.DeleteFeatureTemplate(templateID)
 	if err != nil {
 		return err
@@ -298,15 +312,15 @@ func resourceFeatureTemplateDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func buildFeatureTemplate(d *schema.ResourceData) (*FeatureTemplate, error) {
+func buildFeatureTemplate(d *schema.ResourceData, c *Client) (*FeatureTemplate, error) {
 	template := FeatureTemplate{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
 		Select: SQLExpression{
 			SQL: d.Get("select").(string),
 		},
-		Labels:     expandLabels(d),
-		Attributes: expandAttributes(d),
+		Labels:     expandLabels(d, c),
+		Attributes: expandAttributes(d, c),
 	}
 
 	if d.Get("filter").(string) != "" {
@@ -354,13 +368,11 @@ func buildFeatureTemplate(d *schema.ResourceData) (*FeatureTemplate, error) {
 		template.Type = "event"
 		template.Table = number
 		template.Window = &window
-		entity_restrictions := d.Get("entity_restrictions").([]interface{})
-		if len(entity_restrictions) > 0 {
-			listVal := expandIdentifierList(entity_restrictions)
-			template.EntityRestr = &listVal
-		} else {
-			template.EntityRestr = nil
+		entityRestrictions, err := expandEntityRestrictions(d, c)
+		if err != nil {
+			return nil, err
 		}
+		template.EntityRestr = entityRestrictions
 	} else {
 		template.Type = "row"
 		template.Over = expandIdentifierList(d.Get("over").([]interface{}))
@@ -0,0 +1,184 @@
+package anaml
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestFeatureAggregationWindows(t *testing.T) {
+	cases := []struct {
+		name        string
+		aggregation string
+		window      map[string]interface{}
+		wantErr     string
+	}{
+		{"sum over all events", "sum", nil, ""},
+		{"sum over rows", "sum", map[string]interface{}{"rows": 5}, ""},
+		{"percentage change over hours", "percentagechange", map[string]interface{}{"hours": 6}, ""},
+		{"absolute change over days", "absolutechange", map[string]interface{}{"days": 7}, ""},
+		{"standard score over months", "standardscore", map[string]interface{}{"months": 3}, ""},
+		{"standard score over a duration", "standardscore", map[string]interface{}{"duration": "90d"}, ""},
+		{"percentage change over all events", "percentagechange", nil, "Aggregation percentagechange can't be computed over the open window, set one of: hours, days, months"},
+		{"absolute change over rows", "absolutechange", map[string]interface{}{"rows": 10}, "Aggregation absolutechange can't be computed over the rows window"},
+		{"standard score over all events", "standardscore", nil, "Aggregation standardscore can't be computed over the open window"},
+		{"basket sum over all events", "basketsum", nil, ""},
+		{"basket last over all events", "basketlast", nil, ""},
+		{"basket sum over days", "basketsum", map[string]interface{}{"days": 7}, "Aggregation basketsum is computed over all events, so can't be given a days window"},
+		{"basket max over rows", "basketmax", map[string]interface{}{"rows": 10}, "Aggregation basketmax is computed over all events, so can't be given a rows window"},
+		{"basket min over a duration", "basketmin", map[string]interface{}{"duration": "6mo"}, "Aggregation basketmin is computed over all events, so can't be given a months window"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"name":        "spend",
+				"table":       "1",
+				"select":      "amount",
+				"aggregation": tt.aggregation,
+			}
+			for k, v := range tt.window {
+				config[k] = v
+			}
+
+			_, err := ResourceFeature().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("err = %v, want none", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSameFeatures(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []interface{}
+		want bool
+	}{
+		{"equal", []interface{}{"1", "2"}, []interface{}{"1", "2"}, true},
+		{"reordered", []interface{}{"1", "2", "3"}, []interface{}{"3", "1", "2"}, true},
+		{"duplicated", []interface{}{"1", "2", "1"}, []interface{}{"1", "2"}, true},
+		{"duplicated and reordered", []interface{}{"2", "2", "1"}, []interface{}{"1", "2"}, true},
+		{"both empty", []interface{}{}, nil, true},
+		{"extra feature", []interface{}{"1", "2"}, []interface{}{"1", "2", "3"}, false},
+		{"different feature", []interface{}{"1", "2"}, []interface{}{"1", "3"}, false},
+		{"duplicates hide a missing feature", []interface{}{"1", "1"}, []interface{}{"1", "2"}, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameFeatures(tt.a, tt.b); got != tt.want {
+				t.Errorf("sameFeatures(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenOver(t *testing.T) {
+	cases := []struct {
+		name       string
+		configured []interface{}
+		server     []int
+		want       []interface{}
+	}{
+		{"same order", []interface{}{"1", "2"}, []int{1, 2}, []interface{}{"1", "2"}},
+		{"reordered by the server", []interface{}{"2", "1", "3"}, []int{1, 2, 3}, []interface{}{"2", "1", "3"}},
+		{"duplicate in configuration", []interface{}{"2", "1", "2"}, []int{2, 1}, []interface{}{"2", "1"}},
+		{"duplicate from the server", []interface{}{"1", "2"}, []int{2, 1, 2}, []interface{}{"1", "2"}},
+		{"changed on the server", []interface{}{"1", "2"}, []int{3, 1}, []interface{}{"3", "1"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceFeature().Schema, map[string]interface{}{
+				"name":   "spend",
+				"select": "amount",
+				"entity": "1",
+				"over":   tt.configured,
+			})
+			if got := flattenOver(d, tt.server); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flattenOver(%v) = %v, want %v", tt.server, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWindowDuration(t *testing.T) {
+	cases := []struct {
+		duration string
+		want     *EventWindow
+	}{
+		{"36h", &EventWindow{Type: "hourwindow", Hours: 36}},
+		{"1h", &EventWindow{Type: "hourwindow", Hours: 1}},
+		{"90d", &EventWindow{Type: "daywindow", Days: 90}},
+		{"6mo", &EventWindow{Type: "monthwindow", Months: 6}},
+		{"12mo", &EventWindow{Type: "monthwindow", Months: 12}},
+		{"", nil},
+		{"0h", nil},
+		{"07d", nil},
+		{"-1d", nil},
+		{"1.5h", nil},
+		{"6m", nil},
+		{"2w", nil},
+		{"1y", nil},
+		{"90D", nil},
+		{"90 d", nil},
+		{"d", nil},
+		{"1h30m", nil},
+		{"PT36H", nil},
+		{"99999999999999999999d", nil},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.duration, func(t *testing.T) {
+			got, err := parseWindowDuration(tt.duration)
+			if tt.want == nil {
+				if err == nil {
+					t.Errorf("parseWindowDuration(%q) = %+v, want an error", tt.duration, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseWindowDuration(%q) err = %v", tt.duration, err)
+			}
+			if *got != *tt.want {
+				t.Errorf("parseWindowDuration(%q) = %+v, want %+v", tt.duration, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenWindowDuration(t *testing.T) {
+	cases := []struct {
+		name     string
+		duration string
+		window   *EventWindow
+		want     string
+	}{
+		{"matches the server", "36h", &EventWindow{Type: "hourwindow", Hours: 36}, "36h"},
+		{"changed on the server", "36h", &EventWindow{Type: "hourwindow", Hours: 48}, ""},
+		{"different unit on the server", "30d", &EventWindow{Type: "monthwindow", Months: 1}, ""},
+		{"not configured", "", &EventWindow{Type: "daywindow", Days: 7}, ""},
+		{"no window on the server", "7d", nil, ""},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"name": "spend", "table": "1", "select": "amount"}
+			if tt.duration != "" {
+				config["duration"] = tt.duration
+			}
+			d := schema.TestResourceDataRaw(t, ResourceFeature().Schema, config)
+			if got := flattenWindowDuration(d, tt.window); got != tt.want {
+				t.Errorf("flattenWindowDuration(%+v) = %q, want %q", tt.window, got, tt.want)
+			}
+		})
+	}
+}
@@ -15,11 +15,11 @@ Restrictions limit the labels that can be applied to a given object.
 
 func ResourceLabelRestriction() *schema.Resource {
 	return &schema.Resource{
-		Description: labelDescription,
-		Create:      resourceLabelRestrictionCreate,
-		Read:        resourceLabelRestrictionRead,
-		Update:      resourceLabelRestrictionUpdate,
-		Delete:      resourceLabelRestrictionDelete,
+		Description:   labelDescription,
+		CreateContext: withWarnings(resourceLabelRestrictionCreate),
+		ReadContext:   withDiagnostics(resourceLabelRestrictionRead),
+		UpdateContext: withWarnings(resourceLabelRestrictionUpdate),
+		DeleteContext: withDiagnostics(resourceLabelRestrictionDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -31,12 +31,15 @@ func ResourceLabelRestriction() *schema.Resource {
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"emoji": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEmoji(),
 			},
 			"colour": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateColour(),
+				StateFunc:    normaliseColour,
 			},
 		},
 	}
@@ -68,28 +71,29 @@ func resourceLabelRestrictionRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceLabelRestrictionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceLabelRestrictionCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 	label := composeLabel(d)
-	l, err := c.CreateLabelRestriction(*label)
+	l, warnings, err := c.CreateLabelRestriction(*label)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(l.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(l.ID), resourceLabelRestrictionRead)
 }
 
-func resourceLabelRestrictionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceLabelRestrictionUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	labelID := d.Id()
 	label := composeLabel(d)
-	err := c.UpdateLabelRestriction(labelID, *label)
+	warnings, err := c.UpdateLabelRestriction(labelID, *label)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceLabelRestrictionRead(d, m)
 }
 
 func resourceLabelRestrictionDelete(d *schema.ResourceData, m interface{}) error {
@@ -1,6 +1,7 @@
 package anaml
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -9,6 +10,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var sourceTypes = []string{"s3", "s3a", "jdbc", "hive", "big_query", "gcs", "local", "hdfs", "kafka", "snowflake"}
+
 const sourceDescription = `# Sources
 
 A Source is the physical configuration for the location of root tables.
@@ -22,41 +25,48 @@ Multiple different types of sources are supported:
 - Hive
 - HDFS
 - JDBC
+
+Changing a JDBC or Snowflake source's url, warehouse, database or
+credential username replaces the source, as the server only connects
+when a source is created. Rotating a password or secret is an in-place
+update.
 `
 
 func ResourceSource() *schema.Resource {
 	return &schema.Resource{
-		Description: sourceDescription,
-		Create:      resourceSourceCreate,
-		Read:        resourceSourceRead,
-		Update:      resourceSourceUpdate,
-		Delete:      resourceSourceDelete,
+		Description:   sourceDescription,
+		CreateContext: withWarnings(resourceSourceCreate),
+		ReadContext:   withDiagnostics(resourceSourceRead),
+		UpdateContext: withWarnings(resourceSourceUpdate),
+		DeleteContext: withDiagnostics(resourceSourceDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("source", (*Client).FindSource),
 		},
+		CustomizeDiff: customizeSourceDiff,
 
-		Schema: map[string]*schema.Schema{
+		Schema: auditSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"s3": {
 				Type:         schema.TypeList,
 				Optional:     true,
 				MaxItems:     1,
-				Elem:         s3SourceDestinationSchema(),
-				ExactlyOneOf: []string{"s3", "s3a", "jdbc", "hive", "big_query", "gcs", "local", "hdfs", "kafka", "snowflake"},
+				Elem:         fileSourceSchema(s3SourceDestinationSchema()),
+				ExactlyOneOf: sourceTypes,
 			},
 			"s3a": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     s3aSourceDestinationSchema(),
+				Elem:     fileSourceSchema(s3aSourceDestinationSchema()),
 			},
 			"jdbc": {
 				Type:     schema.TypeList,
@@ -80,25 +90,25 @@ func ResourceSource() *schema.Resource {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     gcsSourceDestinationSchema(),
+				Elem:     fileSourceSchema(gcsSourceDestinationSchema()),
 			},
 			"local": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     localSourceDestinationSchema(),
+				Elem:     fileSourceSchema(localSourceDestinationSchema()),
 			},
 			"hdfs": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     hdfsSourceDestinationSchema(),
+				Elem:     fileSourceSchema(hdfsSourceDestinationSchema()),
 			},
 			"kafka": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     kafkaSourceDestinationSchema(),
+				Elem:     kafkaSourceSchema(),
 			},
 			"snowflake": {
 				Type:     schema.TypeList,
@@ -124,7 +134,8 @@ func ResourceSource() *schema.Resource {
 				Description: "Access rules to attach to the object",
 				Elem:        accessRuleSchema(),
 			},
-		},
+			"deletion_protection": deletionProtectionSchema(),
+		}),
 	}
 }
 
@@ -141,6 +152,18 @@ func s3SourceDestinationSchema() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
+			"endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Overrides the AWS S3 endpoint, for use with S3 compatible stores such as MinIO",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The AWS region of the bucket",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
 			"file_format": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -153,10 +176,12 @@ func s3SourceDestinationSchema() *schema.Resource {
 			"quote_all": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"include_header": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"empty_value": {
 				Type:     schema.TypeString,
@@ -165,10 +190,12 @@ func s3SourceDestinationSchema() *schema.Resource {
 			"ignore_leading_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"ignore_trailing_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"compression": {
 				Type:     schema.TypeString,
@@ -230,10 +257,12 @@ func s3aSourceDestinationSchema() *schema.Resource {
 			"quote_all": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"include_header": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"empty_value": {
 				Type:     schema.TypeString,
@@ -242,10 +271,12 @@ func s3aSourceDestinationSchema() *schema.Resource {
 			"ignore_leading_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"ignore_trailing_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"compression": {
 				Type:     schema.TypeString,
@@ -267,6 +298,30 @@ func s3aSourceDestinationSchema() *schema.Resource {
 	}
 }
 
+// Adds the read options which are only applicable to file based
+// sources to a schema shared with destinations.
+func fileSourceSchema(resource *schema.Resource) *schema.Resource {
+	resource.Schema["recursive_file_lookup"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+		Description: "Recursively load files from nested directories under the path.",
+	}
+	resource.Schema["path_glob_filter"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "A glob pattern which files under the path must match to be read, e.g., `year=*/month=*`.",
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+	resource.Schema["timestamp_timezone"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "The time zone of timestamp columns without one, such as Australia/Sydney. Event tables over the source use it unless they set their own",
+		ValidateFunc: validateTimeZone(),
+	}
+	return resource
+}
+
 func jdbcSourceDestinationSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -278,7 +333,7 @@ func jdbcSourceDestinationSchema() *schema.Resource {
 			"schema": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ValidateFunc: validateSchemaName("give the database in the url instead"),
 			},
 			"credentials_provider": {
 				Type:     schema.TypeList,
@@ -290,6 +345,74 @@ func jdbcSourceDestinationSchema() *schema.Resource {
 	}
 }
 
+// The server can't change a source's type in place, so moving from one
+// type of block to another replaces the source. Other changes within a
+// block, such as editing Kafka properties, are sent with UpdateSource
+// instead, as replacing a source breaks the tables which depend on it.
+// The exception is a change to a JDBC or Snowflake connection's identity,
+// see forceNewOnConnectionChange.
+func customizeSourceDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	var oldType, newType string
+	for _, sourceType := range sourceTypes {
+		o, n := d.GetChange(sourceType)
+		if len(o.([]interface{})) > 0 {
+			oldType = sourceType
+		}
+		if len(n.([]interface{})) > 0 {
+			newType = sourceType
+		}
+	}
+
+	if oldType != "" && newType != "" && oldType != newType {
+		return d.ForceNew(newType)
+	}
+
+	if oldType == "jdbc" && newType == "jdbc" {
+		return forceNewOnConnectionChange(d, "jdbc", "url")
+	}
+	if oldType == "snowflake" && newType == "snowflake" {
+		return forceNewOnConnectionChange(d, "snowflake", "url", "warehouse", "database")
+	}
+	return nil
+}
+
+// The server holds a connection to JDBC and Snowflake sources open, and
+// only reconnects when a source is created. A change to the fields which
+// identify the connection, or to the username the source connects as,
+// therefore replaces the source. Rotating a password or secret, or
+// changing how it is provided, keeps the same identity and is updated in
+// place.
+func forceNewOnConnectionChange(d *schema.ResourceDiff, block string, fields ...string) error {
+	for _, field := range fields {
+		key := block + ".0." + field
+		if d.HasChange(key) {
+			return d.ForceNew(key)
+		}
+	}
+
+	var oldUsername, newUsername, newKey string
+	for _, kind := range []string{"basic", "file", "aws", "gcp"} {
+		key := block + ".0.credentials_provider.0." + kind + ".0.username"
+		o, n := d.GetChange(key)
+		if o.(string) != "" {
+			oldUsername = o.(string)
+		}
+		if n.(string) != "" {
+			newUsername = n.(string)
+			newKey = key
+		}
+	}
+
+	if oldUsername != "" && newUsername != "" && oldUsername != newUsername {
+		return d.ForceNew(newKey)
+	}
+	return nil
+}
+
 func hiveSourceDestinationSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -298,6 +421,21 @@ func hiveSourceDestinationSchema() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
+			"table_properties": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Table properties to set on the Hive table.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"partition_columns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The columns the Hive table is partitioned by.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
 		},
 	}
 }
@@ -307,7 +445,15 @@ func bigQuerySourceSchema() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"path": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Description:  "The table path to read from.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ExactlyOneOf: []string{"big_query.0.path", "big_query.0.query"},
+			},
+			"query": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A SQL query to read from instead of a table path.",
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 		},
@@ -339,10 +485,12 @@ func gcsSourceDestinationSchema() *schema.Resource {
 			"quote_all": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"include_header": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"empty_value": {
 				Type:     schema.TypeString,
@@ -351,10 +499,12 @@ func gcsSourceDestinationSchema() *schema.Resource {
 			"ignore_leading_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"ignore_trailing_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"compression": {
 				Type:     schema.TypeString,
@@ -396,10 +546,12 @@ func localSourceDestinationSchema() *schema.Resource {
 			"quote_all": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"include_header": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"empty_value": {
 				Type:     schema.TypeString,
@@ -408,10 +560,12 @@ func localSourceDestinationSchema() *schema.Resource {
 			"ignore_leading_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"ignore_trailing_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"compression": {
 				Type:     schema.TypeString,
@@ -453,10 +607,12 @@ func hdfsSourceDestinationSchema() *schema.Resource {
 			"quote_all": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"include_header": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"empty_value": {
 				Type:     schema.TypeString,
@@ -465,10 +621,12 @@ func hdfsSourceDestinationSchema() *schema.Resource {
 			"ignore_leading_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"ignore_trailing_whitespace": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"compression": {
 				Type:     schema.TypeString,
@@ -496,7 +654,7 @@ func kafkaSourceDestinationSchema() *schema.Resource {
 			"bootstrap_servers": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ValidateFunc: validateBootstrapServers(),
 			},
 			"schema_registry_url": {
 				Type:         schema.TypeString,
@@ -512,6 +670,37 @@ func kafkaSourceDestinationSchema() *schema.Resource {
 	}
 }
 
+// A Kafka source may also be read as an unbounded stream.
+func kafkaSourceSchema() *schema.Resource {
+	kafka := kafkaSourceDestinationSchema()
+	kafka.Schema["streaming"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the topics are read as an unbounded stream rather than a bounded batch. Feature stores over a streaming source must write to a destination which accepts streaming writes, such as Kafka or an online store",
+	}
+	kafka.Schema["group_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "The consumer group to read the topics as, sent as the group.id Kafka property",
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+	kafka.Schema["auto_offset_reset"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Where to start reading when the consumer group has no committed offset, one of earliest, latest or none. Sent as the auto.offset.reset Kafka property",
+		ValidateFunc: validation.StringInSlice([]string{"earliest", "latest", "none"}, false),
+	}
+	return kafka
+}
+
+// The Kafka properties which Kafka sources set through their own fields,
+// by the name of the field.
+var kafkaSourcePropertyFields = map[string]string{
+	"group.id":          "group_id",
+	"auto.offset.reset": "auto_offset_reset",
+}
+
 func onlineDestinationSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -551,6 +740,92 @@ func bigtableDestinationSchema() *schema.Resource {
 	}
 }
 
+func dynamoDBDestinationSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"table_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Overrides the DynamoDB endpoint, for use with local DynamoDB or dynalite.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"credentials_provider": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     loginCredentialsProviderConfigSchema(),
+			},
+		},
+	}
+}
+
+func cassandraDestinationSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"contact_points": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "A comma separated list of host:port pairs to connect to the cluster with.",
+				ValidateFunc: validateBootstrapServers(),
+			},
+			"keyspace": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"table": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"credentials_provider": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     loginCredentialsProviderConfigSchema(),
+			},
+		},
+	}
+}
+
+func redisDestinationSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"host": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"password": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     secretValueConfigSchema(),
+			},
+			"key_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A prefix added to the keys features are written to.",
+			},
+		},
+	}
+}
+
 func snowflakeSourceDestinationSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -572,13 +847,13 @@ func snowflakeSourceDestinationSchema() *schema.Resource {
 			"schema": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ValidateFunc: validateSchemaName("give the database in the database field instead"),
 			},
 			"credentials_provider": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
-				Elem:     loginCredentialsProviderConfigSchema(),
+				Elem:     snowflakeCredentialsProviderConfigSchema(),
 			},
 		},
 	}
@@ -590,6 +865,7 @@ func accessRuleSchema() *schema.Resource {
 			"resource": {
 				Type:         schema.TypeString,
 				Required:     true,
+				Description:  "The table or path within the source which the rule protects",
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"principals": {
@@ -621,6 +897,12 @@ func maskingRuleSchema() *schema.Resource {
 				MaxItems: 1,
 				Elem:     maskMaskingRuleSchema(),
 			},
+			"regex": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     regexMaskingRuleSchema(),
+			},
 		},
 	}
 }
@@ -628,6 +910,12 @@ func maskingRuleSchema() *schema.Resource {
 func filterMaskingRuleSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
+			"condition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Expression evaluated per principal; the rule only applies when it holds",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
 			"expression": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -640,6 +928,12 @@ func filterMaskingRuleSchema() *schema.Resource {
 func maskMaskingRuleSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
+			"condition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Expression evaluated per principal; the rule only applies when it holds",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
 			"column": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -654,6 +948,35 @@ func maskMaskingRuleSchema() *schema.Resource {
 	}
 }
 
+func regexMaskingRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"condition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Expression evaluated per principal; the rule only applies when it holds",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"column": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"pattern": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "A regular expression matching the parts of the column's values to redact",
+				ValidateFunc: validation.All(validation.StringIsNotEmpty, validation.StringIsValidRegExp),
+			},
+			"replacement": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The text each match is replaced with. Defaults to removing the match",
+			},
+		},
+	}
+}
+
 func resourceSourceRead(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	sourceID := d.Id()
@@ -729,6 +1052,7 @@ func resourceSourceRead(d *schema.ResourceData, m interface{}) error {
 		if err != nil {
 			return err
 		}
+		setCredentialVersion(d, "jdbc", jdbc)
 		if err := d.Set("jdbc", jdbc); err != nil {
 			return err
 		}
@@ -755,7 +1079,8 @@ func resourceSourceRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if source.Type == "kafka" {
-		kafka, err := parseKafkaSource(source)
+		configured, _ := expandSingleMap(d.Get("kafka"))
+		kafka, err := parseKafkaSource(source, configured)
 		if err != nil {
 			return err
 		}
@@ -769,15 +1094,23 @@ func resourceSourceRead(d *schema.ResourceData, m interface{}) error {
 		if err != nil {
 			return err
 		}
+		setCredentialVersion(d, "snowflake", snowflake)
+		setOAuthToken(d, "snowflake", snowflake)
 		if err := d.Set("snowflake", snowflake); err != nil {
 			return err
 		}
 	}
 
-	if err := d.Set("labels", source.Labels); err != nil {
+	if err := setAuditMetadata(d, source.AuditMetadata); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(source.Attributes)); err != nil {
+	if err := d.Set("deletion_protection", d.Get("deletion_protection").(bool)); err != nil {
+		return err
+	}
+	if err := d.Set("labels", flattenLabels(d, c, source.Labels)); err != nil {
+		return err
+	}
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, source.Attributes))); err != nil {
 		return err
 	}
 	if err := d.Set("access_rule", flattenAccessRules(source.AccessRules)); err != nil {
@@ -786,42 +1119,47 @@ func resourceSourceRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceSourceCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSourceCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	source, err := composeSource(d)
+	source, err := composeSource(d, c)
 	if source == nil || err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateSource(*source)
+	e, warnings, err := c.CreateSource(*source)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceSourceRead)
 }
 
-func resourceSourceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSourceUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	sourceID := d.Id()
-	source, err := composeSource(d)
+	source, err := composeSource(d, c)
 	if source == nil || err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateSource(sourceID, *source)
+	warnings, err := c.UpdateSource(sourceID, *source)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceSourceRead(d, m)
 }
 
 func resourceSourceDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	sourceID := d.Id()
 
+	if err := checkDeletionProtection(d, "Source"); err != nil {
+		return err
+	}
+
 	err := c.DeleteSource(sourceID)
 	if err != nil {
 		return err
@@ -839,11 +1177,25 @@ func parseS3Source(source *Source) ([]map[string]interface{}, error) {
 	s3 := make(map[string]interface{})
 	s3["bucket"] = source.Bucket
 	s3["path"] = source.Path
+	// gcs shares this parser but has no endpoint or region
+	if source.Type == "s3" {
+		s3["endpoint"] = source.Endpoint
+		s3["region"] = source.Region
+	}
 
-	fileFormat := parseFileFormat(source.FileFormat)
+	if source.FileFormat == nil {
+		return nil, fmt.Errorf("Source %q of type %s has no file format", source.Name, source.Type)
+	}
+	fileFormat, err := parseFileFormat(source.FileFormat)
+	if err != nil {
+		return nil, err
+	}
 	for k, v := range fileFormat {
 		s3[k] = v
 	}
+	s3["recursive_file_lookup"] = source.RecursiveFileLookup
+	s3["path_glob_filter"] = source.PathGlobFilter
+	s3["timestamp_timezone"] = source.TimestampTimezone
 
 	s3s := make([]map[string]interface{}, 0, 1)
 	s3s = append(s3s, s3)
@@ -862,10 +1214,19 @@ func parseS3ASource(source *Source) ([]map[string]interface{}, error) {
 	s3a["access_key"] = source.AccessKey
 	s3a["secret_key"] = source.SecretKey
 
-	fileFormat := parseFileFormat(source.FileFormat)
+	if source.FileFormat == nil {
+		return nil, fmt.Errorf("Source %q of type %s has no file format", source.Name, source.Type)
+	}
+	fileFormat, err := parseFileFormat(source.FileFormat)
+	if err != nil {
+		return nil, err
+	}
 	for k, v := range fileFormat {
 		s3a[k] = v
 	}
+	s3a["recursive_file_lookup"] = source.RecursiveFileLookup
+	s3a["path_glob_filter"] = source.PathGlobFilter
+	s3a["timestamp_timezone"] = source.TimestampTimezone
 
 	s3as := make([]map[string]interface{}, 0, 1)
 	s3as = append(s3as, s3a)
@@ -881,10 +1242,19 @@ func parseLocalSource(source *Source) ([]map[string]interface{}, error) {
 	local := make(map[string]interface{})
 	local["path"] = source.Path
 
-	fileFormat := parseFileFormat(source.FileFormat)
+	if source.FileFormat == nil {
+		return nil, fmt.Errorf("Source %q of type %s has no file format", source.Name, source.Type)
+	}
+	fileFormat, err := parseFileFormat(source.FileFormat)
+	if err != nil {
+		return nil, err
+	}
 	for k, v := range fileFormat {
 		local[k] = v
 	}
+	local["recursive_file_lookup"] = source.RecursiveFileLookup
+	local["path_glob_filter"] = source.PathGlobFilter
+	local["timestamp_timezone"] = source.TimestampTimezone
 
 	locals := make([]map[string]interface{}, 0, 1)
 	locals = append(locals, local)
@@ -900,6 +1270,9 @@ func parseJDBCSource(source *Source) ([]map[string]interface{}, error) {
 	jdbc["url"] = source.URL
 	jdbc["schema"] = source.Schema
 
+	if source.CredentialsProvider == nil {
+		return nil, fmt.Errorf("Source %q of type %s has no credentials provider", source.Name, source.Type)
+	}
 	credentialsProvider, err := parseLoginCredentialsProviderConfig(source.CredentialsProvider)
 	if err != nil {
 		return nil, err
@@ -918,6 +1291,7 @@ func parseBigQuerySource(source *Source) ([]map[string]interface{}, error) {
 
 	bigQuery := make(map[string]interface{})
 	bigQuery["path"] = source.Path
+	bigQuery["query"] = source.Query
 
 	bigQueries := make([]map[string]interface{}, 0, 1)
 	bigQueries = append(bigQueries, bigQuery)
@@ -931,13 +1305,18 @@ func parseHiveSource(source *Source) ([]map[string]interface{}, error) {
 
 	hive := make(map[string]interface{})
 	hive["database"] = source.Database
+	hive["table_properties"] = source.TableProperties
+	hive["partition_columns"] = source.PartitionColumns
 
 	hives := make([]map[string]interface{}, 0, 1)
 	hives = append(hives, hive)
 	return hives, nil
 }
 
-func parseKafkaSource(source *Source) ([]map[string]interface{}, error) {
+// Parses a Kafka source. The properties which have their own field are
+// only read into it when the configured block sets that field, as they
+// could also be given as property blocks.
+func parseKafkaSource(source *Source, configured map[string]interface{}) ([]map[string]interface{}, error) {
 	if source == nil {
 		return nil, errors.New("Source is null")
 	}
@@ -946,17 +1325,25 @@ func parseKafkaSource(source *Source) ([]map[string]interface{}, error) {
 	kafka["bootstrap_servers"] = source.BootstrapServers
 	kafka["schema_registry_url"] = source.SchemaRegistryURL
 
-	sensitives := make([]map[string]interface{}, len(source.KafkaProperties))
-	for i, v := range source.KafkaProperties {
+	sensitives := make([]map[string]interface{}, 0, len(source.KafkaProperties))
+	for _, v := range source.KafkaProperties {
+		if field, ok := kafkaSourcePropertyFields[v.Key]; ok && v.ValueConfig != nil && v.ValueConfig.Type == "basic" {
+			if value, _ := configured[field].(string); value != "" {
+				kafka[field] = v.ValueConfig.Secret
+				continue
+			}
+		}
+
 		sa, err := parseSensitiveAttribute(&v)
 		if err != nil {
 			return nil, err
 		}
 
-		sensitives[i] = sa
+		sensitives = append(sensitives, sa)
 	}
 
 	kafka["property"] = sensitives
+	kafka["streaming"] = source.Streaming
 
 	kafkas := make([]map[string]interface{}, 0, 1)
 	kafkas = append(kafkas, kafka)
@@ -974,6 +1361,9 @@ func parseSnowflakeSource(source *Source) ([]map[string]interface{}, error) {
 	snowflake["database"] = source.Database
 	snowflake["schema"] = source.Schema
 
+	if source.CredentialsProvider == nil {
+		return nil, fmt.Errorf("Source %q of type %s has no credentials provider", source.Name, source.Type)
+	}
 	credentialsProvider, err := parseLoginCredentialsProviderConfig(source.CredentialsProvider)
 	if err != nil {
 		return nil, err
@@ -985,43 +1375,51 @@ func parseSnowflakeSource(source *Source) ([]map[string]interface{}, error) {
 	return snowflakes, nil
 }
 
-func composeSource(d *schema.ResourceData) (*Source, error) {
+func composeSource(d *schema.ResourceData, c *Client) (*Source, error) {
 	accessRules, err := expandAccessRules(d.Get("access_rule").([]interface{}))
 	if err != nil {
 		return nil, err
 	}
 
 	if s3, _ := expandSingleMap(d.Get("s3")); s3 != nil {
-		fileFormat := composeFileFormat(s3)
+		fileFormat := composeFileFormat(d, "s3")
 		source := Source{
-			Name:        d.Get("name").(string),
-			Description: d.Get("description").(string),
-			Type:        "s3",
-			Bucket:      s3["bucket"].(string),
-			Path:        s3["path"].(string),
-			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
-			AccessRules: accessRules,
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Type:                "s3",
+			Bucket:              s3["bucket"].(string),
+			Path:                s3["path"].(string),
+			Endpoint:            s3["endpoint"].(string),
+			Region:              s3["region"].(string),
+			FileFormat:          fileFormat,
+			RecursiveFileLookup: optionalBool(d, "s3.0.recursive_file_lookup"),
+			PathGlobFilter:      getNullableString(d, "s3.0.path_glob_filter"),
+			TimestampTimezone:   getNullableString(d, "s3.0.timestamp_timezone"),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
 		}
 		return &source, nil
 	}
 
 	if s3a, _ := expandSingleMap(d.Get("s3a")); s3a != nil {
-		fileFormat := composeFileFormat(s3a)
+		fileFormat := composeFileFormat(d, "s3a")
 		source := Source{
-			Name:        d.Get("name").(string),
-			Description: d.Get("description").(string),
-			Type:        "s3a",
-			Bucket:      s3a["bucket"].(string),
-			Path:        s3a["path"].(string),
-			Endpoint:    s3a["endpoint"].(string),
-			AccessKey:   s3a["access_key"].(string),
-			SecretKey:   s3a["secret_key"].(string),
-			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
-			AccessRules: accessRules,
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Type:                "s3a",
+			Bucket:              s3a["bucket"].(string),
+			Path:                s3a["path"].(string),
+			Endpoint:            s3a["endpoint"].(string),
+			AccessKey:           s3a["access_key"].(string),
+			SecretKey:           s3a["secret_key"].(string),
+			FileFormat:          fileFormat,
+			RecursiveFileLookup: optionalBool(d, "s3a.0.recursive_file_lookup"),
+			PathGlobFilter:      getNullableString(d, "s3a.0.path_glob_filter"),
+			TimestampTimezone:   getNullableString(d, "s3a.0.timestamp_timezone"),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
 		}
 		return &source, nil
 	}
@@ -1044,8 +1442,8 @@ func composeSource(d *schema.ResourceData) (*Source, error) {
 			URL:                 jdbc["url"].(string),
 			Schema:              jdbc["schema"].(string),
 			CredentialsProvider: credentialsProvider,
-			Labels:              expandLabels(d),
-			Attributes:          expandAttributes(d),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
 			AccessRules:         accessRules,
 		}
 		return &source, nil
@@ -1053,13 +1451,15 @@ func composeSource(d *schema.ResourceData) (*Source, error) {
 
 	if hive, _ := expandSingleMap(d.Get("hive")); hive != nil {
 		source := Source{
-			Name:        d.Get("name").(string),
-			Description: d.Get("description").(string),
-			Type:        "hive",
-			Database:    hive["database"].(string),
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
-			AccessRules: accessRules,
+			Name:             d.Get("name").(string),
+			Description:      d.Get("description").(string),
+			Type:             "hive",
+			Database:         hive["database"].(string),
+			TableProperties:  expandStringMap(hive["table_properties"].(map[string]interface{})),
+			PartitionColumns: expandStringList(hive["partition_columns"].([]interface{})),
+			Labels:           expandLabels(d, c),
+			Attributes:       expandAttributes(d, c),
+			AccessRules:      accessRules,
 		}
 		return &source, nil
 	}
@@ -1070,55 +1470,65 @@ func composeSource(d *schema.ResourceData) (*Source, error) {
 			Description: d.Get("description").(string),
 			Type:        "bigquery",
 			Path:        bigQuery["path"].(string),
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
+			Query:       bigQuery["query"].(string),
+			Labels:      expandLabels(d, c),
+			Attributes:  expandAttributes(d, c),
 			AccessRules: accessRules,
 		}
 		return &source, nil
 	}
 
 	if gcs, _ := expandSingleMap(d.Get("gcs")); gcs != nil {
-		fileFormat := composeFileFormat(gcs)
+		fileFormat := composeFileFormat(d, "gcs")
 		source := Source{
-			Name:        d.Get("name").(string),
-			Description: d.Get("description").(string),
-			Type:        "gcs",
-			Bucket:      gcs["bucket"].(string),
-			Path:        gcs["path"].(string),
-			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
-			AccessRules: accessRules,
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Type:                "gcs",
+			Bucket:              gcs["bucket"].(string),
+			Path:                gcs["path"].(string),
+			FileFormat:          fileFormat,
+			RecursiveFileLookup: optionalBool(d, "gcs.0.recursive_file_lookup"),
+			PathGlobFilter:      getNullableString(d, "gcs.0.path_glob_filter"),
+			TimestampTimezone:   getNullableString(d, "gcs.0.timestamp_timezone"),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
 		}
 		return &source, nil
 	}
 
 	if local, _ := expandSingleMap(d.Get("local")); local != nil {
-		fileFormat := composeFileFormat(local)
+		fileFormat := composeFileFormat(d, "local")
 		source := Source{
-			Name:        d.Get("name").(string),
-			Description: d.Get("description").(string),
-			Type:        "local",
-			Path:        local["path"].(string),
-			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
-			AccessRules: accessRules,
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Type:                "local",
+			Path:                local["path"].(string),
+			FileFormat:          fileFormat,
+			RecursiveFileLookup: optionalBool(d, "local.0.recursive_file_lookup"),
+			PathGlobFilter:      getNullableString(d, "local.0.path_glob_filter"),
+			TimestampTimezone:   getNullableString(d, "local.0.timestamp_timezone"),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
 		}
 		return &source, nil
 	}
 
 	if hdfs, _ := expandSingleMap(d.Get("hdfs")); hdfs != nil {
-		fileFormat := composeFileFormat(hdfs)
+		fileFormat := composeFileFormat(d, "hdfs")
 		source := Source{
-			Name:        d.Get("name").(string),
-			Description: d.Get("description").(string),
-			Type:        "hdfs",
-			Path:        hdfs["path"].(string),
-			FileFormat:  fileFormat,
-			Labels:      expandLabels(d),
-			Attributes:  expandAttributes(d),
-			AccessRules: accessRules,
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Type:                "hdfs",
+			Path:                hdfs["path"].(string),
+			FileFormat:          fileFormat,
+			RecursiveFileLookup: optionalBool(d, "hdfs.0.recursive_file_lookup"),
+			PathGlobFilter:      getNullableString(d, "hdfs.0.path_glob_filter"),
+			TimestampTimezone:   getNullableString(d, "hdfs.0.timestamp_timezone"),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
+			AccessRules:         accessRules,
 		}
 		return &source, nil
 	}
@@ -1145,6 +1555,23 @@ func composeSource(d *schema.ResourceData) (*Source, error) {
 			sensitives[i] = *sa
 		}
 
+		for _, key := range []string{"group.id", "auto.offset.reset"} {
+			field := kafkaSourcePropertyFields[key]
+			value, _ := kafka[field].(string)
+			if value == "" {
+				continue
+			}
+			for _, sensitive := range sensitives {
+				if sensitive.Key == key {
+					return nil, fmt.Errorf("Kafka property %s is set by %s, remove it from the properties", key, field)
+				}
+			}
+			sensitives = append(sensitives, SensitiveAttribute{
+				Key:         key,
+				ValueConfig: &SecretValueConfig{Type: "basic", Secret: value},
+			})
+		}
+
 		source := Source{
 			Name:              d.Get("name").(string),
 			Description:       d.Get("description").(string),
@@ -1152,8 +1579,9 @@ func composeSource(d *schema.ResourceData) (*Source, error) {
 			BootstrapServers:  kafka["bootstrap_servers"].(string),
 			SchemaRegistryURL: kafka["schema_registry_url"].(string),
 			KafkaProperties:   sensitives,
-			Labels:            expandLabels(d),
-			Attributes:        expandAttributes(d),
+			Streaming:         kafka["streaming"].(bool),
+			Labels:            expandLabels(d, c),
+			Attributes:        expandAttributes(d, c),
 			AccessRules:       accessRules,
 		}
 		return &source, nil
@@ -1179,17 +1607,21 @@ func composeSource(d *schema.ResourceData) (*Source, error) {
 			Warehouse:           snowflake["warehouse"].(string),
 			Database:            snowflake["database"].(string),
 			CredentialsProvider: credentialsProvider,
-			Labels:              expandLabels(d),
-			Attributes:          expandAttributes(d),
+			Labels:              expandLabels(d, c),
+			Attributes:          expandAttributes(d, c),
 			AccessRules:         accessRules,
 		}
 		return &source, nil
 	}
 
-	return nil, errors.New("Invalid source type")
+	return nil, invalidBlockTypeError("source", d, sourceTypes)
 }
 
-func parseFileFormat(fileFormat *FileFormat) map[string]interface{} {
+func parseFileFormat(fileFormat *FileFormat) (map[string]interface{}, error) {
+	if fileFormat == nil {
+		return nil, errors.New("FileFormat is null")
+	}
+
 	fileFormatMap := make(map[string]interface{})
 	fileFormatMap["file_format"] = fileFormat.Type
 	if fileFormat.Type == "csv" {
@@ -1244,47 +1676,71 @@ func parseFileFormat(fileFormat *FileFormat) map[string]interface{} {
 			fileFormatMap["line_separator"] = nil
 		}
 	}
-	return fileFormatMap
+	return fileFormatMap, nil
 }
 
-func composeFileFormat(d map[string]interface{}) *FileFormat {
+// Takes the schema key of a single file based source or destination
+// block (e.g., "s3") and composes its file format. Optional booleans
+// which were not configured are left as nil so they are omitted.
+func composeFileFormat(d *schema.ResourceData, key string) *FileFormat {
+	m, _ := expandSingleMap(d.Get(key))
+	prefix := key + ".0."
+
 	fileFormat := FileFormat{
-		Type: d["file_format"].(string),
+		Type: m["file_format"].(string),
 	}
 
-	if d["file_format"] == "csv" {
-		if compression, ok := d["compression"].(string); ok {
+	if m["file_format"] == "csv" {
+		if compression, ok := m["compression"].(string); ok {
 			fileFormat.Compression = &compression
 		}
-		if dateFormat, ok := d["date_format"].(string); ok {
+		if dateFormat, ok := m["date_format"].(string); ok {
 			fileFormat.DateFormat = &dateFormat
 		}
-		if emptyValue, ok := d["empty_value"].(string); ok {
+		if emptyValue, ok := m["empty_value"].(string); ok {
 			fileFormat.EmptyValue = &emptyValue
 		}
-		if ignoreLeadingWhiteSpace, ok := d["ignore_leading_whitespace"].(bool); ok {
-			fileFormat.IgnoreLeadingWhiteSpace = &ignoreLeadingWhiteSpace
-		}
-		if ignoreTrailingWhiteSpace, ok := d["ignore_trailing_whitespace"].(bool); ok {
-			fileFormat.IgnoreTrailingWhiteSpace = &ignoreTrailingWhiteSpace
-		}
-		if includeHeader, ok := d["include_header"].(bool); ok {
-			fileFormat.IncludeHeader = &includeHeader
-		}
-		if quoteAll, ok := d["quote_all"].(bool); ok {
-			fileFormat.QuoteAll = &quoteAll
-		}
-		if sep, ok := d["field_separator"].(string); ok {
+		fileFormat.IgnoreLeadingWhiteSpace = optionalBool(d, prefix+"ignore_leading_whitespace")
+		fileFormat.IgnoreTrailingWhiteSpace = optionalBool(d, prefix+"ignore_trailing_whitespace")
+		fileFormat.IncludeHeader = optionalBool(d, prefix+"include_header")
+		fileFormat.QuoteAll = optionalBool(d, prefix+"quote_all")
+		if sep, ok := m["field_separator"].(string); ok {
 			fileFormat.Sep = &sep
 		}
-		if timestampFormat, ok := d["timestamp_format"].(string); ok {
+		if timestampFormat, ok := m["timestamp_format"].(string); ok {
 			fileFormat.TimestampFormat = &timestampFormat
 		}
-		if lineSep, ok := d["line_separator"].(string); ok {
+		if lineSep, ok := m["line_separator"].(string); ok {
 			fileFormat.LineSep = &lineSep
 		}
 	}
 
+	if m["file_format"] == "orc" {
+		if orc, _ := expandSingleMap(m["orc"]); orc != nil {
+			if columns := expandStringList(orc["bloom_filter_columns"].([]interface{})); len(columns) > 0 {
+				fileFormat.BloomFilterColumns = columns
+			}
+			if stripeSize, _ := orc["stripe_size"].(int); stripeSize > 0 {
+				fileFormat.StripeSize = &stripeSize
+			}
+			if compression, _ := orc["compression"].(string); compression != "" {
+				fileFormat.Compression = &compression
+			}
+		}
+	}
+
+	if m["file_format"] == "parquet" {
+		if parquet, _ := expandSingleMap(m["parquet"]); parquet != nil {
+			if rowGroupSize, _ := parquet["row_group_size"].(int); rowGroupSize > 0 {
+				fileFormat.RowGroupSize = &rowGroupSize
+			}
+			if pageSize, _ := parquet["page_size"].(int); pageSize > 0 {
+				fileFormat.PageSize = &pageSize
+			}
+			fileFormat.EnableDictionary = optionalBool(d, prefix+"parquet.0.enable_dictionary")
+		}
+	}
+
 	return &fileFormat
 }
 
@@ -1335,6 +1791,13 @@ func expandMaskingRules(maskingRules []interface{}) ([]MaskingRule, error) {
 			}
 			res = append(res, *parsed)
 		}
+		if regexMaskingRule, _ := expandSingleMap(val["regex"]); regexMaskingRule != nil {
+			parsed, err := composeRegexMaskingRule(regexMaskingRule)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, *parsed)
+		}
 	}
 
 	return res, nil
@@ -1344,6 +1807,7 @@ func composeFilterMaskingRule(d map[string]interface{}) (*MaskingRule, error) {
 	return &MaskingRule{
 		Type:       "filter",
 		Expression: d["expression"].(string),
+		Condition:  maskingRuleCondition(d),
 	}, nil
 }
 func composeMaskMaskingRule(d map[string]interface{}) (*MaskingRule, error) {
@@ -1351,9 +1815,29 @@ func composeMaskMaskingRule(d map[string]interface{}) (*MaskingRule, error) {
 		Type:       "mask",
 		Column:     d["column"].(string),
 		Expression: d["expression"].(string),
+		Condition:  maskingRuleCondition(d),
+	}, nil
+}
+func composeRegexMaskingRule(d map[string]interface{}) (*MaskingRule, error) {
+	replacement := d["replacement"].(string)
+	return &MaskingRule{
+		Type:        "regex",
+		Column:      d["column"].(string),
+		Pattern:     d["pattern"].(string),
+		Replacement: &replacement,
+		Condition:   maskingRuleCondition(d),
 	}, nil
 }
 
+// An empty condition means the rule applies to every principal, so it is
+// left off the request entirely.
+func maskingRuleCondition(d map[string]interface{}) *string {
+	if condition, _ := d["condition"].(string); condition != "" {
+		return &condition
+	}
+	return nil
+}
+
 func validateFileFormat() schema.SchemaValidateFunc {
 	return validation.StringInSlice([]string{"csv", "orc", "parquet"}, false)
 }
@@ -1377,14 +1861,32 @@ func flatternMaskingRules(maskingRules []MaskingRule) []map[string]([]map[string
 		if maskingRule.Type == "filter" {
 			nest := make(map[string]interface{})
 			nest["expression"] = maskingRule.Expression
+			if maskingRule.Condition != nil {
+				nest["condition"] = *maskingRule.Condition
+			}
 			single["filter"] = []map[string]interface{}{nest}
 		}
 		if maskingRule.Type == "mask" {
 			nest := make(map[string]interface{})
 			nest["column"] = maskingRule.Column
 			nest["expression"] = maskingRule.Expression
+			if maskingRule.Condition != nil {
+				nest["condition"] = *maskingRule.Condition
+			}
 			single["mask"] = []map[string]interface{}{nest}
 		}
+		if maskingRule.Type == "regex" {
+			nest := make(map[string]interface{})
+			nest["column"] = maskingRule.Column
+			nest["pattern"] = maskingRule.Pattern
+			if maskingRule.Replacement != nil {
+				nest["replacement"] = *maskingRule.Replacement
+			}
+			if maskingRule.Condition != nil {
+				nest["condition"] = *maskingRule.Condition
+			}
+			single["regex"] = []map[string]interface{}{nest}
+		}
 		res = append(res, single)
 	}
 	return res
@@ -0,0 +1,533 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParseBucketSourceSetsEveryBucketType(t *testing.T) {
+	parsers := []struct {
+		key   string
+		parse func(*Source) ([]map[string]interface{}, error)
+	}{
+		{"s3", parseS3Source},
+		{"s3a", parseS3ASource},
+		{"gcs", parseS3Source},
+		{"local", parseLocalSource},
+		{"hdfs", parseLocalSource},
+	}
+
+	for _, tt := range parsers {
+		t.Run(tt.key, func(t *testing.T) {
+			source := &Source{
+				Name:       "example",
+				Type:       tt.key,
+				Bucket:     "bucket",
+				Path:       "/path",
+				Endpoint:   "https://minio.example.com",
+				Region:     "ap-southeast-2",
+				FileFormat: &FileFormat{Type: "parquet"},
+			}
+			parsed, err := tt.parse(source)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := schema.TestResourceDataRaw(t, ResourceSource().Schema, map[string]interface{}{})
+			if err := d.Set(tt.key, parsed); err != nil {
+				t.Fatalf("setting %s: %v", tt.key, err)
+			}
+			if got := d.Get(tt.key + ".0.file_format"); got != "parquet" {
+				t.Errorf("file_format = %v, want parquet", got)
+			}
+		})
+	}
+}
+
+func TestParseS3SourceKeepsEndpointAndRegion(t *testing.T) {
+	source := &Source{
+		Type:       "s3",
+		Bucket:     "bucket",
+		Path:       "/path",
+		Endpoint:   "https://minio.example.com",
+		Region:     "ap-southeast-2",
+		FileFormat: &FileFormat{Type: "parquet"},
+	}
+	parsed, err := parseS3Source(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, ResourceSource().Schema, map[string]interface{}{})
+	if err := d.Set("s3", parsed); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("s3.0.endpoint"); got != source.Endpoint {
+		t.Errorf("endpoint = %v, want %v", got, source.Endpoint)
+	}
+	if got := d.Get("s3.0.region"); got != source.Region {
+		t.Errorf("region = %v, want %v", got, source.Region)
+	}
+}
+
+func TestAccessRuleResourceValidation(t *testing.T) {
+	validate := accessRuleSchema().Schema["resource"].ValidateFunc
+	cases := []struct {
+		resource string
+		valid    bool
+	}{
+		{"customers", true},
+		{"events/2024", true},
+		{"*", true},
+		{"", false},
+		{"  ", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.resource, func(t *testing.T) {
+			_, errs := validate(tt.resource, "resource")
+			if valid := len(errs) == 0; valid != tt.valid {
+				t.Errorf("valid = %v, want %v (errors: %v)", valid, tt.valid, errs)
+			}
+		})
+	}
+}
+
+func TestSourceDiffUpdatesKafkaPropertiesInPlace(t *testing.T) {
+	kafka := func(value string) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "events",
+			"kafka": []interface{}{map[string]interface{}{
+				"bootstrap_servers":   "kafka:9092",
+				"schema_registry_url": "http://registry:8081",
+				"property": []interface{}{
+					map[string]interface{}{"key": "max.poll.records", "value": value},
+				},
+			}},
+		}
+	}
+	s3 := map[string]interface{}{
+		"name": "events",
+		"s3": []interface{}{map[string]interface{}{
+			"bucket":      "bucket",
+			"path":        "/events",
+			"file_format": "parquet",
+		}},
+	}
+
+	cases := []struct {
+		name            string
+		config          map[string]interface{}
+		wantChange      string
+		wantRequiresNew bool
+	}{
+		{"edited property", kafka("1000"), "kafka.0.property.0.value", false},
+		{"new source type", s3, "s3.#", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceSource().Schema, kafka("500"))
+			d.SetId("1")
+
+			diff, err := ResourceSource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(tt.config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil || diff.Attributes[tt.wantChange] == nil {
+				t.Fatalf("diff = %v, want a change to %s", diff, tt.wantChange)
+			}
+			if diff.RequiresNew() != tt.wantRequiresNew {
+				t.Errorf("RequiresNew() = %v, want %v", diff.RequiresNew(), tt.wantRequiresNew)
+			}
+		})
+	}
+}
+
+func TestSourceDiffReplacesOnTypeChange(t *testing.T) {
+	bucket := func(key, path string) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "events",
+			key: []interface{}{map[string]interface{}{
+				"bucket":      "bucket",
+				"path":        path,
+				"file_format": "parquet",
+			}},
+		}
+	}
+
+	cases := []struct {
+		name            string
+		id              string
+		config          map[string]interface{}
+		wantRequiresNew bool
+	}{
+		{"path edited", "1", bucket("s3", "/other"), false},
+		{"s3 to gcs", "1", bucket("gcs", "/events"), true},
+		{"s3 to s3a", "1", bucket("s3a", "/events"), true},
+		{"s3 to hive", "1", map[string]interface{}{"name": "events", "hive": []interface{}{map[string]interface{}{"database": "events"}}}, true},
+		{"not yet created", "", bucket("gcs", "/events"), false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceSource().Schema, bucket("s3", "/events"))
+			d.SetId(tt.id)
+
+			diff, err := ResourceSource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(tt.config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.Empty() {
+				t.Fatalf("diff is empty, want a change")
+			}
+			if diff.RequiresNew() != tt.wantRequiresNew {
+				t.Errorf("RequiresNew() = %v, want %v", diff.RequiresNew(), tt.wantRequiresNew)
+			}
+		})
+	}
+}
+
+func TestParseSourceWithoutRequiredBlock(t *testing.T) {
+	cases := []struct {
+		name    string
+		typ     string
+		parse   func(*Source) ([]map[string]interface{}, error)
+		wantErr string
+	}{
+		{"s3", "s3", parseS3Source, `Source "events" of type s3 has no file format`},
+		{"gcs", "gcs", parseS3Source, `Source "events" of type gcs has no file format`},
+		{"s3a", "s3a", parseS3ASource, `Source "events" of type s3a has no file format`},
+		{"local", "local", parseLocalSource, `Source "events" of type local has no file format`},
+		{"hdfs", "hdfs", parseLocalSource, `Source "events" of type hdfs has no file format`},
+		{"jdbc", "jdbc", parseJDBCSource, `Source "events" of type jdbc has no credentials provider`},
+		{"snowflake", "snowflake", parseSnowflakeSource, `Source "events" of type snowflake has no credentials provider`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parse(&Source{Name: "events", Type: tt.typ})
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestComposeSourceKafkaPropertiesPayload(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  map[string]interface{}
+		wantKey bool
+	}{
+		{"s3", map[string]interface{}{
+			"name": "events",
+			"s3":   []interface{}{map[string]interface{}{"bucket": "bucket", "path": "/events", "file_format": "parquet"}},
+		}, false},
+		{"kafka without properties", map[string]interface{}{
+			"name":  "events",
+			"kafka": []interface{}{map[string]interface{}{"bootstrap_servers": "kafka:9092", "schema_registry_url": "http://registry:8081"}},
+		}, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceSource().Schema, tt.config)
+			source, err := composeSource(d, &Client{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := json.Marshal(source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var payload map[string]json.RawMessage
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := payload["kafkaPropertiesProviders"]; ok != tt.wantKey {
+				t.Errorf("payload %s has kafkaPropertiesProviders = %v, want %v", body, ok, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestSourceDiffReplacesOnConnectionChange(t *testing.T) {
+	basic := func(username, password string) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"basic": []interface{}{map[string]interface{}{"username": username, "password": password}},
+		}}
+	}
+	file := func(username string) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"file": []interface{}{map[string]interface{}{"username": username, "filepath": "/secrets/password"}},
+		}}
+	}
+	jdbc := func(url string, credentials []interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "warehouse",
+			"jdbc": []interface{}{map[string]interface{}{
+				"url":                  url,
+				"schema":               "public",
+				"credentials_provider": credentials,
+			}},
+		}
+	}
+	snowflake := func(warehouse, database string, credentials []interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "warehouse",
+			"snowflake": []interface{}{map[string]interface{}{
+				"url":                  "https://example.snowflakecomputing.com",
+				"warehouse":            warehouse,
+				"database":             database,
+				"schema":               "public",
+				"credentials_provider": credentials,
+			}},
+		}
+	}
+
+	cases := []struct {
+		name            string
+		old, new        map[string]interface{}
+		wantRequiresNew bool
+	}{
+		{"jdbc url", jdbc("jdbc:postgresql://a/db", basic("anaml", "one")), jdbc("jdbc:postgresql://b/db", basic("anaml", "one")), true},
+		{"jdbc username", jdbc("jdbc:postgresql://a/db", basic("anaml", "one")), jdbc("jdbc:postgresql://a/db", basic("other", "one")), true},
+		{"jdbc password rotated", jdbc("jdbc:postgresql://a/db", basic("anaml", "one")), jdbc("jdbc:postgresql://a/db", basic("anaml", "two")), false},
+		{"jdbc password moved to a file", jdbc("jdbc:postgresql://a/db", basic("anaml", "one")), jdbc("jdbc:postgresql://a/db", file("anaml")), false},
+		{"jdbc username changed with provider", jdbc("jdbc:postgresql://a/db", basic("anaml", "one")), jdbc("jdbc:postgresql://a/db", file("other")), true},
+		{"snowflake warehouse", snowflake("compute", "analytics", basic("anaml", "one")), snowflake("large", "analytics", basic("anaml", "one")), true},
+		{"snowflake database", snowflake("compute", "analytics", basic("anaml", "one")), snowflake("compute", "raw", basic("anaml", "one")), true},
+		{"snowflake password rotated", snowflake("compute", "analytics", basic("anaml", "one")), snowflake("compute", "analytics", basic("anaml", "two")), false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceSource().Schema, tt.old)
+			d.SetId("1")
+
+			diff, err := ResourceSource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(tt.new), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.Empty() {
+				t.Fatalf("diff is empty, want a change")
+			}
+			if diff.RequiresNew() != tt.wantRequiresNew {
+				t.Errorf("RequiresNew() = %v, want %v", diff.RequiresNew(), tt.wantRequiresNew)
+			}
+		})
+	}
+}
+
+func TestSourceRejectsQualifiedSchemaNames(t *testing.T) {
+	credentials := []interface{}{map[string]interface{}{
+		"basic": []interface{}{map[string]interface{}{"username": "anaml", "password": "secret"}},
+	}}
+	cases := []struct {
+		name     string
+		block    string
+		source   map[string]interface{}
+		wantHint string
+	}{
+		{"jdbc", "jdbc", map[string]interface{}{
+			"url":                  "jdbc:postgresql://host/db",
+			"schema":               "db.public",
+			"credentials_provider": credentials,
+		}, "give the database in the url instead"},
+		{"snowflake", "snowflake", map[string]interface{}{
+			"url":                  "https://example.snowflakecomputing.com",
+			"warehouse":            "compute",
+			"database":             "analytics",
+			"schema":               "analytics.public",
+			"credentials_provider": credentials,
+		}, "give the database in the database field instead"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := ResourceSource().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":   "warehouse",
+				tt.block: []interface{}{tt.source},
+			}))
+			found := false
+			for _, diag := range diags {
+				if strings.Contains(diag.Summary, tt.wantHint) || strings.Contains(diag.Detail, tt.wantHint) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("diags = %v, want an error saying %q", diags, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestKafkaSourcePropertyFieldsRoundTrip(t *testing.T) {
+	kafka := func(block map[string]interface{}) map[string]interface{} {
+		block["bootstrap_servers"] = "kafka:9092"
+		block["schema_registry_url"] = "http://registry:8081"
+		return map[string]interface{}{"name": "events", "kafka": []interface{}{block}}
+	}
+
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{"fields", kafka(map[string]interface{}{
+			"group_id":          "anaml",
+			"auto_offset_reset": "earliest",
+		})},
+		{"property blocks", kafka(map[string]interface{}{
+			"property": []interface{}{
+				map[string]interface{}{"key": "group.id", "value": "anaml"},
+				map[string]interface{}{"key": "auto.offset.reset", "value": "earliest"},
+			},
+		})},
+		{"field and property block", kafka(map[string]interface{}{
+			"group_id": "anaml",
+			"property": []interface{}{
+				map[string]interface{}{"key": "auto.offset.reset", "value": "earliest"},
+			},
+		})},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceSource().Schema, tt.config)
+			source, err := composeSource(d, &Client{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			configured, _ := expandSingleMap(d.Get("kafka"))
+			parsed, err := parseKafkaSource(source, configured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Set("kafka", parsed); err != nil {
+				t.Fatal(err)
+			}
+			d.SetId("5")
+
+			diff, err := ResourceSource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(tt.config), &Client{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !diff.Empty() {
+				t.Errorf("diff = %v, want none", diff)
+			}
+
+			// Reading the properties back must send the same ones again.
+			again, err := composeSource(d, &Client{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(again.KafkaProperties, source.KafkaProperties) {
+				t.Errorf("sent %+v after reading, want %+v", again.KafkaProperties, source.KafkaProperties)
+			}
+		})
+	}
+}
+
+func TestBigQuerySourceRoundTrip(t *testing.T) {
+	cases := []struct {
+		name  string
+		block map[string]interface{}
+		want  string
+	}{
+		{"path", map[string]interface{}{"path": "project.dataset.customers"}, `"path":"project.dataset.customers"`},
+		{"query", map[string]interface{}{"query": "SELECT * FROM dataset.customers"}, `"query":"SELECT * FROM dataset.customers"`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"name": "customers", "big_query": []interface{}{tt.block}}
+			d := schema.TestResourceDataRaw(t, ResourceSource().Schema, config)
+			source, err := composeSource(d, &Client{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Only the configured one of path and query is sent.
+			body, err := json.Marshal(source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(body), tt.want) || strings.Count(string(body), `"path"`)+strings.Count(string(body), `"query"`) != 1 {
+				t.Errorf("sent %s, want only %s", body, tt.want)
+			}
+
+			read := Source{}
+			if err := json.Unmarshal(body, &read); err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := parseBigQuerySource(&read)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Set("big_query", parsed); err != nil {
+				t.Fatal(err)
+			}
+			d.SetId("5")
+
+			diff, err := ResourceSource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), &Client{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !diff.Empty() {
+				t.Errorf("diff = %v, want none", diff)
+			}
+		})
+	}
+}
+
+func TestBigQuerySourceNeedsPathOrQuery(t *testing.T) {
+	cases := []struct {
+		name    string
+		block   map[string]interface{}
+		wantErr bool
+	}{
+		{"path", map[string]interface{}{"path": "project.dataset.customers"}, false},
+		{"query", map[string]interface{}{"query": "SELECT 1"}, false},
+		{"both", map[string]interface{}{"path": "project.dataset.customers", "query": "SELECT 1"}, true},
+		{"neither", map[string]interface{}{}, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"name": "customers", "big_query": []interface{}{tt.block}}
+			diags := ResourceSource().Validate(terraform.NewResourceConfigRaw(config))
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("errors = %v, want error %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSourcePassesCredentialsErrorsThrough(t *testing.T) {
+	cases := []struct {
+		name  string
+		typ   string
+		parse func(*Source) ([]map[string]interface{}, error)
+	}{
+		{"jdbc", "jdbc", parseJDBCSource},
+		{"snowflake", "snowflake", parseSnowflakeSource},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parse(&Source{Name: "events", Type: tt.typ, CredentialsProvider: &LoginCredentialsProviderConfig{Type: "kerberos"}})
+			want := "LoginCredentialsProviderConfig.Type contains an unexpected value: kerberos"
+			if err == nil || err.Error() != want {
+				t.Errorf("err = %v, want %q", err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package anaml
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const sparkPropertyBundleDescription = `# Spark Property Bundles
+
+A Spark Property Bundle is a named set of Spark properties which is shared between jobs.
+
+Its identifier can be given in the ` + "`cluster_property_sets`" + ` of Feature Stores, Table Monitoring
+and Table Caching jobs, so that Spark tuning is kept in one place.
+`
+
+func ResourceSparkPropertyBundle() *schema.Resource {
+	return &schema.Resource{
+		Description:   sparkPropertyBundleDescription,
+		CreateContext: withWarnings(resourceSparkPropertyBundleCreate),
+		ReadContext:   withDiagnostics(resourceSparkPropertyBundleRead),
+		UpdateContext: withWarnings(resourceSparkPropertyBundleUpdate),
+		DeleteContext: withDiagnostics(resourceSparkPropertyBundleDelete),
+		Importer: &schema.ResourceImporter{
+			State: importByIDOrName("spark property bundle", (*Client).FindSparkPropertyBundle),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAnamlName(),
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
+			},
+			"additional_spark_properties": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Spark properties to set on the jobs which use the bundle",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSparkPropertyBundleRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	bundleID := d.Id()
+
+	bundle, err := c.GetSparkPropertyBundle(bundleID)
+	if err != nil {
+		return err
+	}
+	if bundle == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("name", bundle.Name); err != nil {
+		return err
+	}
+	if err := d.Set("description", bundle.Description); err != nil {
+		return err
+	}
+	if err := d.Set("additional_spark_properties", bundle.AdditionalSparkProperties); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceSparkPropertyBundleCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
+	c := m.(*Client)
+	bundle := composeSparkPropertyBundle(d)
+	e, warnings, err := c.CreateSparkPropertyBundle(*bundle)
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceSparkPropertyBundleRead)
+}
+
+func resourceSparkPropertyBundleUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
+	c := m.(*Client)
+	d.Partial(true)
+	bundleID := d.Id()
+	bundle := composeSparkPropertyBundle(d)
+	warnings, err := c.UpdateSparkPropertyBundle(bundleID, *bundle)
+	if err != nil {
+		return warnings, err
+	}
+
+	d.Partial(false)
+	return warnings, resourceSparkPropertyBundleRead(d, m)
+}
+
+func resourceSparkPropertyBundleDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	bundleID := d.Id()
+
+	err := c.DeleteSparkPropertyBundle(bundleID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// The properties are always sent as a map, never null, so that an empty
+// map reads back the same as an unset one.
+func composeSparkPropertyBundle(d *schema.ResourceData) *SparkPropertyBundle {
+	properties := expandStringMap(d.Get("additional_spark_properties").(map[string]interface{}))
+	if properties == nil {
+		properties = make(map[string]string)
+	}
+	return &SparkPropertyBundle{
+		Name:                      d.Get("name").(string),
+		Description:               d.Get("description").(string),
+		AdditionalSparkProperties: properties,
+	}
+}
@@ -76,24 +76,25 @@ such as reference data tables used for lookups.
 // ResourceTable ...
 func ResourceTable() *schema.Resource {
 	return &schema.Resource{
-		Description: tableDescription,
-		Create:      resourceTableCreate,
-		Read:        resourceTableRead,
-		Update:      resourceTableUpdate,
-		Delete:      resourceTableDelete,
+		Description:   tableDescription,
+		CreateContext: withWarnings(resourceTableCreate),
+		ReadContext:   withDiagnostics(resourceTableRead),
+		UpdateContext: withWarnings(resourceTableUpdate),
+		DeleteContext: withDiagnostics(resourceTableDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("table", (*Client).FindTableByName),
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: auditSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"source": {
 				Type:         schema.TypeList,
@@ -120,8 +121,9 @@ func ResourceTable() *schema.Resource {
 				RequiredWith: []string{"expression"},
 
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 
@@ -133,10 +135,11 @@ func ResourceTable() *schema.Resource {
 			},
 
 			"entity_mapping": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ValidateFunc:  validateAnamlIdentifier(),
-				ConflictsWith: []string{"event"},
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
+				ConflictsWith:    []string{"event"},
 			},
 			"extra_features": {
 				Type:          schema.TypeList,
@@ -145,8 +148,9 @@ func ResourceTable() *schema.Resource {
 				ConflictsWith: []string{"event"},
 
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 			"labels": {
@@ -161,7 +165,8 @@ func ResourceTable() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
-		},
+			"deletion_protection": deletionProtectionSchema(),
+		}),
 	}
 }
 
@@ -192,9 +197,10 @@ func sourceSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"source": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"folder": {
 				Type:         schema.TypeString,
@@ -220,9 +226,10 @@ func eventStoreSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"store": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"topic": {
 				Type:         schema.TypeString,
@@ -230,9 +237,10 @@ func eventStoreSchema() *schema.Resource {
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"entity": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 		},
 	}
@@ -294,44 +302,55 @@ func resourceTableRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	if err := d.Set("labels", table.Labels); err != nil {
+	if err := setAuditMetadata(d, table.AuditMetadata); err != nil {
+		return err
+	}
+	if err := d.Set("deletion_protection", d.Get("deletion_protection").(bool)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(table.Attributes)); err != nil {
+	if err := d.Set("labels", flattenLabels(d, c, table.Labels)); err != nil {
+		return err
+	}
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, table.Attributes))); err != nil {
 		return err
 	}
 	return nil
 }
 
-func resourceTableCreate(d *schema.ResourceData, m interface{}) error {
+func resourceTableCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	table := buildTable(d)
-	e, err := c.CreateTable(*table)
+	table := buildTable(d, c)
+	e, warnings, err := c.CreateTable(*table)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceTableRead)
 }
 
-func resourceTableUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceTableUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	tableID := d.Id()
-	table := buildTable(d)
+	table := buildTable(d, c)
 
-	err := c.UpdateTable(tableID, *table)
+	warnings, err := c.UpdateTable(tableID, *table)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceTableRead(d, m)
 }
 
 func resourceTableDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	tableID := d.Id()
 
+	if err := checkDeletionProtection(d, "Table"); err != nil {
+		return err
+	}
+
 	err := c.DeleteTable(tableID)
 	if err != nil {
 		return err
@@ -340,13 +359,13 @@ func resourceTableDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func buildTable(d *schema.ResourceData) *Table {
+func buildTable(d *schema.ResourceData, c *Client) *Table {
 	table := Table{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
 		EventInfo:   expandEntityDescription(d),
-		Labels:      expandLabels(d),
-		Attributes:  expandAttributes(d),
+		Labels:      expandLabels(d, c),
+		Attributes:  expandAttributes(d, c),
 	}
 
 	if d.Get("expression").(string) != "" {
@@ -11,10 +11,10 @@ import (
 
 func ResourceTableCaching() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTableCachingCreate,
-		Read:   resourceTableCachingRead,
-		Update: resourceTableCachingUpdate,
-		Delete: resourceTableCachingDelete,
+		CreateContext: withWarnings(resourceTableCachingCreate),
+		ReadContext:   withDiagnostics(resourceTableCachingRead),
+		UpdateContext: withWarnings(resourceTableCachingUpdate),
+		DeleteContext: withDiagnostics(resourceTableCachingDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -25,8 +25,9 @@ func ResourceTableCaching() *schema.Resource {
 				Required: true,
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"prefix_url": {
 				Type:     schema.TypeString,
@@ -52,7 +53,8 @@ func ResourceTableCaching() *schema.Resource {
 			"retainment": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "How long cached data is kept, as an ISO-8601 duration such as PT48H",
+				ValidateFunc: validateAnamlDuration(),
 			},
 			"daily_schedule": {
 				Type:          schema.TypeList,
@@ -71,19 +73,23 @@ func ResourceTableCaching() *schema.Resource {
 			"principal": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Description:  "The user or group the job runs as, given as an ID, user:name or group:name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"cluster": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The ID of the cluster to run on. Defaults to the provider's default_cluster",
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"cluster_property_sets": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 		},
@@ -144,9 +150,10 @@ func resourceTableCachingV0() *schema.Resource {
 				ConflictsWith: []string{"daily_schedule"},
 			},
 			"cluster": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 		},
 	}
@@ -231,7 +238,11 @@ func resourceTableCachingRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	if TableCaching.Principal != nil {
-		if err := d.Set("principal", strconv.Itoa(*TableCaching.Principal)); err != nil {
+		principal, err := flattenPrincipal(d, c, "principal", *TableCaching.Principal)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("principal", principal); err != nil {
 			return err
 		}
 	}
@@ -239,7 +250,11 @@ func resourceTableCachingRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set(loc, plan); err != nil {
 		return err
 	}
-	if err := d.Set("cluster", strconv.Itoa(TableCaching.Cluster)); err != nil {
+	cluster, err := flattenCluster(d, c, TableCaching.Cluster)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("cluster", cluster); err != nil {
 		return err
 	}
 	if err := d.Set("cluster_property_sets", identifierList(TableCaching.ClusterPropertySets)); err != nil {
@@ -280,40 +295,41 @@ func resourceTableCachingRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceTableCachingCreate(d *schema.ResourceData, m interface{}) error {
+func resourceTableCachingCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	TableCaching, err := composeTableCaching(d)
+	TableCaching, err := composeTableCaching(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateTableCaching(*TableCaching)
+	e, warnings, err := c.CreateTableCaching(*TableCaching)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceTableCachingRead)
 }
 
-func resourceTableCachingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceTableCachingUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	TableCachingID := d.Id()
-	TableCaching, err := composeTableCaching(d)
+	TableCaching, err := composeTableCaching(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateTableCaching(TableCachingID, *TableCaching)
+	warnings, err := c.UpdateTableCaching(TableCachingID, *TableCaching)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceTableCachingRead(d, m)
 }
 
-func composeTableCaching(d *schema.ResourceData) (*TableCaching, error) {
-	cluster, err := strconv.Atoi(d.Get("cluster").(string))
+func composeTableCaching(d *schema.ResourceData, c *Client) (*TableCaching, error) {
+	cluster, err := expandCluster(d, c)
 	if err != nil {
 		return nil, err
 	}
@@ -332,14 +348,9 @@ func composeTableCaching(d *schema.ResourceData) (*TableCaching, error) {
 		}
 	}
 
-	var principal (*int) = nil
-	principalRaw, principalOk := d.GetOk("principal")
-	if principalOk {
-		principal_, err := strconv.Atoi(principalRaw.(string))
-		if err != nil {
-			return nil, err
-		}
-		principal = &principal_
+	principal, err := resolvePrincipal(c, d, "principal")
+	if err != nil {
+		return nil, err
 	}
 
 	var retainment *string
@@ -6,14 +6,15 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceTableMonitoring() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTableMonitoringCreate,
-		Read:   resourceTableMonitoringRead,
-		Update: resourceTableMonitoringUpdate,
-		Delete: resourceTableMonitoringDelete,
+		CreateContext: withWarnings(resourceTableMonitoringCreate),
+		ReadContext:   withDiagnostics(resourceTableMonitoringRead),
+		UpdateContext: withWarnings(resourceTableMonitoringUpdate),
+		DeleteContext: withDiagnostics(resourceTableMonitoringDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -24,8 +25,9 @@ func ResourceTableMonitoring() *schema.Resource {
 				Required: true,
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"include": {
 				Type:        schema.TypeList,
@@ -45,7 +47,8 @@ func ResourceTableMonitoring() *schema.Resource {
 			"principal": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Description:  "The user or group the job runs as, given as an ID, user:name or group:name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"enabled": {
 				Type:     schema.TypeBool,
@@ -66,16 +69,19 @@ func ResourceTableMonitoring() *schema.Resource {
 				ConflictsWith: []string{"daily_schedule"},
 			},
 			"cluster": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The ID of the cluster to run on. Defaults to the provider's default_cluster",
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"cluster_property_sets": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 		},
@@ -92,10 +98,10 @@ func ResourceTableMonitoring() *schema.Resource {
 
 func ResourceTableMonitoringV0() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTableMonitoringCreate,
-		Read:   resourceTableMonitoringRead,
-		Update: resourceTableMonitoringUpdate,
-		Delete: resourceTableMonitoringDelete,
+		CreateContext: withWarnings(resourceTableMonitoringCreate),
+		ReadContext:   withDiagnostics(resourceTableMonitoringRead),
+		UpdateContext: withWarnings(resourceTableMonitoringUpdate),
+		DeleteContext: withDiagnostics(resourceTableMonitoringDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -164,6 +170,7 @@ func excludedTables() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"exclude": {
 				Type:        schema.TypeSet,
+				Set:         hashIdentifier,
 				Description: "Tables to monitor with this job",
 				Optional:    true,
 
@@ -181,6 +188,7 @@ func monitoringTables() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"tables": {
 				Type:        schema.TypeSet,
+				Set:         hashIdentifier,
 				Description: "Tables to monitor with this job",
 				Required:    true,
 				MinItems:    1,
@@ -237,11 +245,19 @@ func resourceTableMonitoringRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	if TableMonitoring.Principal != nil {
-		if err := d.Set("principal", strconv.Itoa(*TableMonitoring.Principal)); err != nil {
+		principal, err := flattenPrincipal(d, c, "principal", *TableMonitoring.Principal)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("principal", principal); err != nil {
 			return err
 		}
 	}
-	if err := d.Set("cluster", strconv.Itoa(TableMonitoring.Cluster)); err != nil {
+	cluster, err := flattenCluster(d, c, TableMonitoring.Cluster)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("cluster", cluster); err != nil {
 		return err
 	}
 	if err := d.Set("cluster_property_sets", identifierList(TableMonitoring.ClusterPropertySets)); err != nil {
@@ -270,52 +286,48 @@ func resourceTableMonitoringRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceTableMonitoringCreate(d *schema.ResourceData, m interface{}) error {
+func resourceTableMonitoringCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	TableMonitoring, err := composeTableMonitoring(d)
+	TableMonitoring, err := composeTableMonitoring(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateTableMonitoring(*TableMonitoring)
+	e, warnings, err := c.CreateTableMonitoring(*TableMonitoring)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceTableMonitoringRead)
 }
 
-func resourceTableMonitoringUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceTableMonitoringUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	TableMonitoringID := d.Id()
-	TableMonitoring, err := composeTableMonitoring(d)
+	TableMonitoring, err := composeTableMonitoring(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateTableMonitoring(TableMonitoringID, *TableMonitoring)
+	warnings, err := c.UpdateTableMonitoring(TableMonitoringID, *TableMonitoring)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceTableMonitoringRead(d, m)
 }
 
-func composeTableMonitoring(d *schema.ResourceData) (*TableMonitoring, error) {
-	cluster, err := strconv.Atoi(d.Get("cluster").(string))
+func composeTableMonitoring(d *schema.ResourceData, c *Client) (*TableMonitoring, error) {
+	cluster, err := expandCluster(d, c)
 	if err != nil {
 		return nil, err
 	}
 
-	var principal (*int) = nil
-	principalRaw, principalOk := d.GetOk("principal")
-	if principalOk {
-		principal_, err := strconv.Atoi(principalRaw.(string))
-		if err != nil {
-			return nil, err
-		}
-		principal = &principal_
+	principal, err := resolvePrincipal(c, d, "principal")
+	if err != nil {
+		return nil, err
 	}
 
 	plan, err := expandTableMonitoringPlan(d)
@@ -9,12 +9,12 @@ import (
 
 func ResourceUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserCreate,
-		Read:   resourceUserRead,
-		Update: resourceUserUpdate,
-		Delete: resourceUserDelete,
+		CreateContext: withWarnings(resourceUserCreate),
+		ReadContext:   withDiagnostics(resourceUserRead),
+		UpdateContext: withWarnings(resourceUserUpdate),
+		DeleteContext: withDiagnostics(resourceUserDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("user", (*Client).FindUser),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -84,7 +84,7 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
+func resourceUserCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 	user := User{
 		Name:      d.Get("name").(string),
@@ -95,17 +95,17 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 		Roles:     mapRolesToBackend(expandStringList(d.Get("roles").([]interface{}))),
 	}
 
-	e, err := c.CreateUser(user)
+	e, warnings, err := c.CreateUser(user)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceUserRead)
 }
 
-func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceUserUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	userID := d.Id()
 	user := User{
 		Name:      d.Get("name").(string),
@@ -115,20 +115,22 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 		Roles:     mapRolesToBackend(expandStringList(d.Get("roles").([]interface{}))),
 	}
 
-	err := c.UpdateUser(userID, user)
+	warnings, err := c.UpdateUser(userID, user)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
 	if d.HasChange("password") {
 		password := getNullableString(d, "password")
-		err = c.UpdateUserPassword(userID, password)
+		passwordWarnings, err := c.UpdateUserPassword(userID, password)
+		warnings = append(warnings, passwordWarnings...)
 		if err != nil {
-			return err
+			return warnings, err
 		}
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceUserRead(d, m)
 }
 
 func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
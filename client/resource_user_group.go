@@ -9,12 +9,12 @@ import (
 
 func ResourceUserGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserGroupCreate,
-		Read:   resourceUserGroupRead,
-		Update: resourceUserGroupUpdate,
-		Delete: resourceUserGroupDelete,
+		CreateContext: withWarnings(resourceUserGroupCreate),
+		ReadContext:   withDiagnostics(resourceUserGroupRead),
+		UpdateContext: withWarnings(resourceUserGroupUpdate),
+		DeleteContext: withDiagnostics(resourceUserGroupDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("user group", (*Client).FindUserGroup),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -23,8 +23,9 @@ func ResourceUserGroup() *schema.Resource {
 				Required: true,
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"roles": {
 				Type:     schema.TypeList,
@@ -102,12 +103,12 @@ func resourceUserGroupRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceUserGroupCreate(d *schema.ResourceData, m interface{}) error {
+func resourceUserGroupCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 
 	groupMembers, err := expandUserGroupMembers(d.Get("members").(*schema.Set).List())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	UserGroup := UserGroup{
 		Name:            d.Get("name").(string),
@@ -117,22 +118,22 @@ func resourceUserGroupCreate(d *schema.ResourceData, m interface{}) error {
 		ExternalGroupID: getNullableString(d, "external_group_id"),
 	}
 
-	ug, err := c.CreateUserGroup(UserGroup)
+	ug, warnings, err := c.CreateUserGroup(UserGroup)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(ug.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(ug.ID), resourceUserGroupRead)
 }
 
-func resourceUserGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceUserGroupUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	UserGroupID := d.Id()
 
 	groupMembers, err := expandUserGroupMembers(d.Get("members").(*schema.Set).List())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	UserGroup := UserGroup{
 		Name:            d.Get("name").(string),
@@ -142,12 +143,13 @@ func resourceUserGroupUpdate(d *schema.ResourceData, m interface{}) error {
 		ExternalGroupID: getNullableString(d, "external_group_id"),
 	}
 
-	err = c.UpdateUserGroup(UserGroupID, UserGroup)
+	warnings, err := c.UpdateUserGroup(UserGroupID, UserGroup)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceUserGroupRead(d, m)
 }
 
 func resourceUserGroupDelete(d *schema.ResourceData, m interface{}) error {
@@ -1,6 +1,7 @@
 package anaml
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -16,13 +17,14 @@ View materialisation offer a simple way of scheduling arbitrary SQL table expres
 
 func ResourceViewMaterialisationJob() *schema.Resource {
 	return &schema.Resource{
-		Description: viewMaterialisationDescription,
-		Create:      resourceViewMaterialisationJobCreate,
-		Read:        resourceViewMaterialisationJobRead,
-		Update:      resourceViewMaterialisationJobUpdate,
-		Delete:      resourceViewMaterialisationJobDelete,
+		Description:   viewMaterialisationDescription,
+		CreateContext: withWarnings(resourceViewMaterialisationJobCreate),
+		ReadContext:   withDiagnostics(resourceViewMaterialisationJobRead),
+		UpdateContext: withWarnings(resourceViewMaterialisationJobUpdate),
+		DeleteContext: withDiagnostics(resourceViewMaterialisationJobDelete),
+		CustomizeDiff: customizeViewMaterialisationJobDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("view materialisation job", (*Client).FindViewMaterialisationJobByName),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -32,13 +34,15 @@ func ResourceViewMaterialisationJob() *schema.Resource {
 				ValidateFunc: validateAnamlName(),
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"principal": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Description:  "The user or group the job runs as, given as an ID, user:name or group:name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"include_metadata": {
 				Type:     schema.TypeBool,
@@ -62,24 +66,29 @@ func ResourceViewMaterialisationJob() *schema.Resource {
 			"usagettl": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "How long the materialised views are kept after they were last used, as an ISO-8601 duration such as PT48H",
+				ValidateFunc: validateAnamlDuration(),
 			},
 			"view": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem:     viewMaterialisationSpecSchema(),
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "The views to materialise, each with its own destination and write settings",
+				Elem:        viewMaterialisationSpecSchema(),
 			},
 			"cluster": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The ID of the cluster to run on. Defaults to the provider's default_cluster",
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"cluster_property_sets": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateAnamlIdentifier(),
+					Type:             schema.TypeString,
+					ValidateFunc:     validateAnamlIdentifier(),
+					DiffSuppressFunc: suppressIdentifierDiff,
 				},
 			},
 			"additional_spark_properties": {
@@ -123,20 +132,40 @@ func viewMaterialisationSpecSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"table": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateAnamlIdentifier(),
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The Table to materialise",
+				ValidateFunc:     validateAnamlIdentifier(),
+				DiffSuppressFunc: suppressIdentifierDiff,
 			},
 			"destination": {
-				Type:     schema.TypeList,
-				Required: true,
-				MaxItems: 1,
-				Elem:     destinationSchema(),
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Where this view is written. The folder, table or topic, save mode and options apply to this view only",
+				Elem:        destinationSchema(),
 			},
 		},
 	}
 }
 
+func customizeViewMaterialisationJobDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	oldViews, newViews := d.GetChange("view")
+	for i, view := range newViews.([]interface{}) {
+		val, _ := view.(map[string]interface{})
+		destinations, _ := val["destination"].([]interface{})
+		var oldDestinations []interface{}
+		if i < len(oldViews.([]interface{})) {
+			prev, _ := oldViews.([]interface{})[i].(map[string]interface{})
+			oldDestinations, _ = prev["destination"].([]interface{})
+		}
+		if err := checkDestinationOptionKeys(fmt.Sprintf("view.%d.destination", i), oldDestinations, destinations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func resourceViewMaterialisationJobRead(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	ViewMaterialisationJobID := d.Id()
@@ -184,12 +213,16 @@ func resourceViewMaterialisationJobRead(d *schema.ResourceData, m interface{}) e
 	}
 
 	if ViewMaterialisationJob.Principal != nil {
-		if err := d.Set("principal", strconv.Itoa(*ViewMaterialisationJob.Principal)); err != nil {
+		principal, err := flattenPrincipal(d, c, "principal", *ViewMaterialisationJob.Principal)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("principal", principal); err != nil {
 			return err
 		}
 	}
 
-	views, err := flattenViewMaterialisationSpec(ViewMaterialisationJob.Views)
+	views, err := flattenViewMaterialisationSpec(c, ViewMaterialisationJob.Views, d.Get("view").([]interface{}))
 	if err != nil {
 		return err
 	}
@@ -205,7 +238,11 @@ func resourceViewMaterialisationJobRead(d *schema.ResourceData, m interface{}) e
 	} else {
 		d.Set("usagettl", nil)
 	}
-	if err := d.Set("cluster", strconv.Itoa(ViewMaterialisationJob.Cluster)); err != nil {
+	cluster, err := flattenCluster(d, c, ViewMaterialisationJob.Cluster)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("cluster", cluster); err != nil {
 		return err
 	}
 	if err := d.Set("cluster_property_sets", identifierList(ViewMaterialisationJob.ClusterPropertySets)); err != nil {
@@ -214,10 +251,10 @@ func resourceViewMaterialisationJobRead(d *schema.ResourceData, m interface{}) e
 	if err := d.Set("additional_spark_properties", ViewMaterialisationJob.AdditionalSparkProperties); err != nil {
 		return err
 	}
-	if err := d.Set("labels", ViewMaterialisationJob.Labels); err != nil {
+	if err := d.Set("labels", flattenLabels(d, c, ViewMaterialisationJob.Labels)); err != nil {
 		return err
 	}
-	if err := d.Set("attribute", flattenAttributes(ViewMaterialisationJob.Attributes)); err != nil {
+	if err := d.Set("attribute", flattenAttributes(omitDefaultAttributes(d, c, ViewMaterialisationJob.Attributes))); err != nil {
 		return err
 	}
 
@@ -249,50 +286,46 @@ func resourceViewMaterialisationJobRead(d *schema.ResourceData, m interface{}) e
 	return err
 }
 
-func resourceViewMaterialisationJobCreate(d *schema.ResourceData, m interface{}) error {
+func resourceViewMaterialisationJobCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
-	ViewMaterialisationJob, err := composeViewMaterialisationJob(d)
+	ViewMaterialisationJob, err := composeViewMaterialisationJob(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	e, err := c.CreateViewMaterialisationJob(*ViewMaterialisationJob)
+	e, warnings, err := c.CreateViewMaterialisationJob(*ViewMaterialisationJob)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return warnings, readAfterCreate(d, m, strconv.Itoa(e.ID), resourceViewMaterialisationJobRead)
 }
 
-func resourceViewMaterialisationJobUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceViewMaterialisationJobUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	ViewMaterialisationJobID := d.Id()
-	vm, err := composeViewMaterialisationJob(d)
+	vm, err := composeViewMaterialisationJob(d, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = c.UpdateViewMaterialisationJob(ViewMaterialisationJobID, *vm)
+	warnings, err := c.UpdateViewMaterialisationJob(ViewMaterialisationJobID, *vm)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceViewMaterialisationJobRead(d, m)
 }
 
-func composeViewMaterialisationJob(d *schema.ResourceData) (*ViewMaterialisationJob, error) {
-	var principal (*int) = nil
-	principalRaw, principalOk := d.GetOk("principal")
-	if principalOk {
-		principal_, err := strconv.Atoi(principalRaw.(string))
-		if err != nil {
-			return nil, err
-		}
-		principal = &principal_
+func composeViewMaterialisationJob(d *schema.ResourceData, c *Client) (*ViewMaterialisationJob, error) {
+	principal, err := resolvePrincipal(c, d, "principal")
+	if err != nil {
+		return nil, err
 	}
 
-	cluster, err := strconv.Atoi(d.Get("cluster").(string))
+	cluster, err := expandCluster(d, c)
 	if err != nil {
 		return nil, err
 	}
@@ -324,7 +357,7 @@ func composeViewMaterialisationJob(d *schema.ResourceData) (*ViewMaterialisation
 		usageTTL = &usageTTLstr
 	}
 
-	views, err := expandViewMaterialisationSpec(d)
+	views, err := expandViewMaterialisationSpec(d, c)
 	if err != nil {
 		return nil, err
 	}
@@ -338,8 +371,8 @@ func composeViewMaterialisationJob(d *schema.ResourceData) (*ViewMaterialisation
 		Cluster:                   cluster,
 		ClusterPropertySets:       expandIdentifierList(d.Get("cluster_property_sets").([]interface{})),
 		AdditionalSparkProperties: additionalSparkProperties,
-		Labels:                    expandLabels(d),
-		Attributes:                expandAttributes(d),
+		Labels:                    expandLabels(d, c),
+		Attributes:                expandAttributes(d, c),
 		VersionTarget:             versionTarget,
 	}
 
@@ -381,18 +414,18 @@ func resourceViewMaterialisationJobDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func expandViewMaterialisationSpec(d *schema.ResourceData) ([]ViewMaterialisationSpec, error) {
+func expandViewMaterialisationSpec(d *schema.ResourceData, c *Client) ([]ViewMaterialisationSpec, error) {
 	views := d.Get("view").([]interface{})
 	res := make([]ViewMaterialisationSpec, 0, len(views))
 
-	for _, view := range views {
+	for i, view := range views {
 		val, _ := view.(map[string]interface{})
 		table, err := strconv.Atoi(val["table"].(string))
 		if err != nil {
 			return nil, err
 		}
 
-		destinations, err := expandDestinationReferences(val["destination"].([]interface{}))
+		destinations, err := expandDestinationReferences(c, d, fmt.Sprintf("view.%d.destination", i))
 		if err != nil {
 			return nil, err
 		}
@@ -412,13 +445,21 @@ func expandViewMaterialisationSpec(d *schema.ResourceData) ([]ViewMaterialisatio
 	return res, nil
 }
 
-func flattenViewMaterialisationSpec(views []ViewMaterialisationSpec) ([]interface{}, error) {
+func flattenViewMaterialisationSpec(c *Client, views []ViewMaterialisationSpec, configured []interface{}) ([]interface{}, error) {
 	res := make([]interface{}, 0, len(views))
 
-	for _, view := range views {
+	for i, view := range views {
 		single := make(map[string]interface{})
 		single["table"] = strconv.Itoa(view.Table)
-		destinations, err := flattenDestinationReferences([]DestinationReference{view.Destination})
+
+		var configuredDestinations []interface{}
+		if i < len(configured) {
+			if val, ok := configured[i].(map[string]interface{}); ok {
+				configuredDestinations, _ = val["destination"].([]interface{})
+			}
+		}
+
+		destinations, err := flattenDestinationReferences(c, []DestinationReference{view.Destination}, configuredDestinations)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,33 @@
+package anaml
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestViewMaterialisationJobDiffRejectsDuplicateOptionKeys(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "job",
+		"view": []interface{}{
+			map[string]interface{}{
+				"table": "1",
+				"destination": []interface{}{
+					map[string]interface{}{
+						"destination": "2",
+						"folder":      []interface{}{map[string]interface{}{"path": "/out", "save_mode": "overwrite"}},
+						"option":      []interface{}{map[string]interface{}{"key": "compression", "value": "gzip"}},
+						"options":     map[string]interface{}{"compression": "snappy"},
+					},
+				},
+			},
+		},
+	})
+
+	_, err := ResourceViewMaterialisationJob().Diff(context.Background(), nil, config, &Client{})
+	if err == nil || !strings.Contains(err.Error(), `"compression"`) {
+		t.Fatalf("err = %v, want a duplicate compression key error", err)
+	}
+}
@@ -1,6 +1,8 @@
 package anaml
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,13 +20,13 @@ as to automate downstream systems using features generated by Anaml.
 
 func ResourceWebhook() *schema.Resource {
 	return &schema.Resource{
-		Description: webhooksDescription,
-		Create:      resourceWebhookCreate,
-		Read:        resourceWebhookRead,
-		Update:      resourceWebhookUpdate,
-		Delete:      resourceWebhookDelete,
+		Description:   webhooksDescription,
+		CreateContext: withWarnings(resourceWebhookCreate),
+		ReadContext:   withDiagnostics(resourceWebhookRead),
+		UpdateContext: withWarnings(resourceWebhookUpdate),
+		DeleteContext: withDiagnostics(resourceWebhookDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importByIDOrName("webhook", (*Client).FindWebhook),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -34,13 +36,26 @@ func ResourceWebhook() *schema.Resource {
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressWhitespaceDiff,
 			},
 			"url": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"content_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The Content-Type the webhook payload is sent with.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"header": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Additional HTTP headers to send with the webhook payload",
+				Elem:        webhookHeaderSchema(),
+			},
 			"merge_requests": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -89,6 +104,29 @@ func ResourceWebhook() *schema.Resource {
 				MaxItems: 1,
 				Elem:     &schema.Resource{},
 			},
+			"test_on_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send a ping event to the webhook after it is created, failing if the endpoint does not respond with a 2xx status.",
+			},
+		},
+	}
+}
+
+func webhookHeaderSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"value": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
 		},
 	}
 }
@@ -115,6 +153,12 @@ func resourceWebhookRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("url", webhook.URL); err != nil {
 		return err
 	}
+	if err := d.Set("content_type", webhook.ContentType); err != nil {
+		return err
+	}
+	if err := d.Set("header", flattenWebhookHeaders(webhook.Headers)); err != nil {
+		return err
+	}
 	if err := d.Set("merge_requests", flattenEmpty(webhook.MergeRequests)); err != nil {
 		return err
 	}
@@ -142,12 +186,14 @@ func resourceWebhookRead(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceWebhookCreate(d *schema.ResourceData, m interface{}) error {
+func resourceWebhookCreate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
 	webhook := Webhook{
 		Name:                 d.Get("name").(string),
 		Description:          d.Get("description").(string),
 		URL:                  d.Get("url").(string),
+		ContentType:          getNullableString(d, "content_type"),
+		Headers:              expandWebhookHeaders(d.Get("header").(*schema.Set).List()),
 		MergeRequests:        expandEmpty(d.Get("merge_requests").([]interface{})),
 		MergeRequestComments: expandEmpty(d.Get("merge_request_comments").([]interface{})),
 		Commits:              expandEmpty(d.Get("commits").([]interface{})),
@@ -158,22 +204,41 @@ func resourceWebhookCreate(d *schema.ResourceData, m interface{}) error {
 		EventStoreRuns:       expandEmpty(d.Get("event_store_runs").([]interface{})),
 	}
 
-	e, err := c.CreateWebhook(webhook)
+	e, warnings, err := c.CreateWebhook(webhook)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	d.SetId(strconv.Itoa(e.ID))
-	return err
+	if err := readAfterCreate(d, m, strconv.Itoa(e.ID), resourceWebhookRead); err != nil {
+		return warnings, err
+	}
+
+	if d.Get("test_on_create").(bool) {
+		result, err := c.TestWebhook(d.Id())
+		if err != nil {
+			return warnings, err
+		}
+		if result == nil {
+			return warnings, fmt.Errorf("Webhook %s could not be tested: not found", d.Id())
+		}
+		if result.Status < 200 || result.Status >= 300 {
+			return warnings, fmt.Errorf("Webhook %s test failed. Endpoint %s responded with status: %d, body: %s", d.Id(), webhook.URL, result.Status, result.Body)
+		}
+	}
+
+	return warnings, err
 }
 
-func resourceWebhookUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWebhookUpdate(d *schema.ResourceData, m interface{}) ([]string, error) {
 	c := m.(*Client)
+	d.Partial(true)
 	webhookID := d.Id()
 	webhook := Webhook{
 		Name:                 d.Get("name").(string),
 		Description:          d.Get("description").(string),
 		URL:                  d.Get("url").(string),
+		ContentType:          getNullableString(d, "content_type"),
+		Headers:              expandWebhookHeaders(d.Get("header").(*schema.Set).List()),
 		MergeRequests:        expandEmpty(d.Get("merge_requests").([]interface{})),
 		MergeRequestComments: expandEmpty(d.Get("merge_request_comments").([]interface{})),
 		Commits:              expandEmpty(d.Get("commits").([]interface{})),
@@ -184,12 +249,13 @@ func resourceWebhookUpdate(d *schema.ResourceData, m interface{}) error {
 		EventStoreRuns:       expandEmpty(d.Get("event_store_runs").([]interface{})),
 	}
 
-	err := c.UpdateWebhook(webhookID, webhook)
+	warnings, err := c.UpdateWebhook(webhookID, webhook)
 	if err != nil {
-		return err
+		return warnings, err
 	}
 
-	return nil
+	d.Partial(false)
+	return warnings, resourceWebhookRead(d, m)
 }
 
 func resourceWebhookDelete(d *schema.ResourceData, m interface{}) error {
@@ -204,6 +270,45 @@ func resourceWebhookDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func expandWebhookHeaders(drs []interface{}) []WebhookHeader {
+	res := make([]WebhookHeader, 0, len(drs))
+	for _, dr := range drs {
+		val, _ := dr.(map[string]interface{})
+		res = append(res, WebhookHeader{
+			Name:  val["name"].(string),
+			Value: val["value"].(string),
+		})
+	}
+	sortWebhookHeaders(res)
+	return res
+}
+
+func flattenWebhookHeaders(headers []WebhookHeader) []map[string]interface{} {
+	sorted := make([]WebhookHeader, len(headers))
+	copy(sorted, headers)
+	sortWebhookHeaders(sorted)
+
+	res := make([]map[string]interface{}, 0, len(sorted))
+	for _, header := range sorted {
+		single := make(map[string]interface{})
+		single["name"] = header.Name
+		single["value"] = header.Value
+		res = append(res, single)
+	}
+	return res
+}
+
+// Headers are sent and stored in name order so that the payload is
+// deterministic regardless of how they were declared.
+func sortWebhookHeaders(headers []WebhookHeader) {
+	sort.SliceStable(headers, func(i, j int) bool {
+		if headers[i].Name == headers[j].Name {
+			return headers[i].Value < headers[j].Value
+		}
+		return headers[i].Name < headers[j].Name
+	})
+}
+
 func expandEmpty(drs []interface{}) *struct{} {
 	if len(drs) == 0 {
 		return nil
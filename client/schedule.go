@@ -120,7 +120,7 @@ func parseDailySchedule(schedule *Schedule) ([]map[string]interface{}, error) {
 		dailySchedule["start_time_of_day"] = *schedule.StartTimeOfDay
 	}
 
-	if schedule.RetryPolicy.Type == "fixed" {
+	if schedule.RetryPolicy != nil && schedule.RetryPolicy.Type == "fixed" {
 		fixedRetryPolicy, err := parseFixedRetryPolicy(schedule.RetryPolicy)
 		if err != nil {
 			return nil, err
@@ -140,7 +140,7 @@ func parseCronSchedule(schedule *Schedule) ([]map[string]interface{}, error) {
 	cronSchedule := make(map[string]interface{})
 	cronSchedule["cron_string"] = schedule.CronString
 
-	if schedule.RetryPolicy.Type == "fixed" {
+	if schedule.RetryPolicy != nil && schedule.RetryPolicy.Type == "fixed" {
 		fixedRetryPolicy, err := parseFixedRetryPolicy(schedule.RetryPolicy)
 		if err != nil {
 			return nil, err
@@ -65,36 +65,44 @@ func parseSecretProviderConfig(secretProvider *SecretValueConfig) (map[string]in
 }
 
 func composeSensitiveAttribute(d map[string]interface{}) (*SensitiveAttribute, error) {
+	valueConfig, err := composeSecretValueConfig(d)
+	if err != nil {
+		return nil, fmt.Errorf("SensitiveAttribute. Coudn't parse Sensitive Attribute")
+	}
+
 	sensitive := SensitiveAttribute{
-		Key: d["key"].(string),
+		Key:         d["key"].(string),
+		ValueConfig: valueConfig,
 	}
 
+	return &sensitive, nil
+}
+
+func composeSecretValueConfig(d map[string]interface{}) (*SecretValueConfig, error) {
 	if d["value"] != nil && d["value"] != "" {
-		sensitive.ValueConfig = &SecretValueConfig{
+		return &SecretValueConfig{
 			Type:   "basic",
 			Secret: d["value"].(string),
-		}
+		}, nil
 	} else if file, _ := expandSingleMap(d["file"]); file != nil {
-		sensitive.ValueConfig = &SecretValueConfig{
+		return &SecretValueConfig{
 			Type:     "file",
 			FilePath: file["filepath"].(string),
-		}
+		}, nil
 	} else if aws, _ := expandSingleMap(d["aws"]); aws != nil {
-		sensitive.ValueConfig = &SecretValueConfig{
+		return &SecretValueConfig{
 			Type:     "awssm",
 			SecretId: aws["secret_id"].(string),
-		}
+		}, nil
 	} else if gcp, _ := expandSingleMap(d["gcp"]); gcp != nil {
-		sensitive.ValueConfig = &SecretValueConfig{
+		return &SecretValueConfig{
 			Type:          "gcpsm",
 			SecretProject: gcp["secret_project"].(string),
 			SecretId:      gcp["secret_id"].(string),
-		}
-	} else {
-		return nil, fmt.Errorf("SensitiveAttribute. Coudn't parse Sensitive Attribute")
+		}, nil
 	}
 
-	return &sensitive, nil
+	return nil, fmt.Errorf("SecretValueConfig. Couldn't parse secret value")
 }
 
 func sensitiveAttributeSchema() *schema.Resource {
@@ -131,6 +139,36 @@ func sensitiveAttributeSchema() *schema.Resource {
 	}
 }
 
+func secretValueConfigSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"value": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"file": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     fileSecretProviderConfigSchema(),
+			},
+			"aws": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     awsSecretProviderConfigSchema(),
+			},
+			"gcp": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     gcpSecretProviderConfigSchema(),
+			},
+		},
+	}
+}
+
 func fileSecretProviderConfigSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -0,0 +1,117 @@
+package anaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func (c *Client) GetSparkPropertyBundle(bundleID string) (*SparkPropertyBundle, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/spark-property-bundle/%s", c.HostURL, bundleID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, nil
+	}
+
+	bundle := SparkPropertyBundle{}
+	err = json.Unmarshal(body, &bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bundle, nil
+}
+
+func (c *Client) FindSparkPropertyBundle(name string) (*SparkPropertyBundle, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/spark-property-bundle", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("name", name)
+	req.URL.RawQuery = q.Encode()
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	bundle := SparkPropertyBundle{}
+	found, err := unmarshalNamed(body, "Spark property bundle", name, &bundle)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &bundle, nil
+}
+
+func (c *Client) CreateSparkPropertyBundle(creationRequest SparkPropertyBundle) (*SparkPropertyBundle, []string, error) {
+	rb, err := json.Marshal(creationRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/spark-property-bundle", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, warnings, err := c.doWriteRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	V, err := unmarshalCreatedID(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	creationRequest.ID = V
+	return &creationRequest, warnings, nil
+}
+
+func (c *Client) UpdateSparkPropertyBundle(bundleID string, creationRequest SparkPropertyBundle) ([]string, error) {
+	rb, err := json.Marshal(creationRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/spark-property-bundle/%s", c.HostURL, bundleID), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	_, warnings, err := c.doWriteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return warnings, nil
+}
+
+func (c *Client) DeleteSparkPropertyBundle(bundleID string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/spark-property-bundle/%s", c.HostURL, bundleID), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -4,16 +4,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"net"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/apparentlymart/go-textseg/textseg"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
 var identifierPattern = regexp.MustCompile(`^[0-9]+$`)
+var schemaNamePattern = regexp.MustCompile(`^[^.\s]+$`)
 
 // Takes the result of flatmap. Expand for an array of strings
 // and returns a []string
@@ -42,6 +47,19 @@ func expandIdentifierList(configured []interface{}) []int {
 	return vs
 }
 
+// Takes a map of strings from the schema and returns a map[string]string,
+// or nil if the map is empty.
+func expandStringMap(configured map[string]interface{}) map[string]string {
+	if len(configured) == 0 {
+		return nil
+	}
+	vs := make(map[string]string, len(configured))
+	for k, v := range configured {
+		vs[k] = v.(string)
+	}
+	return vs
+}
+
 func expandSingleMap(value interface{}) (map[string]interface{}, error) {
 	if value == nil {
 		return nil, errors.New("Value is null")
@@ -64,6 +82,23 @@ func expandSingleMap(value interface{}) (map[string]interface{}, error) {
 	return single, nil
 }
 
+// Builds the error for when none of the mutually exclusive type blocks
+// of a resource (e.g., the "s3" or "jdbc" block of a source) could be
+// composed, reporting which blocks were present in the configuration.
+func invalidBlockTypeError(kind string, d *schema.ResourceData, blocks []string) error {
+	found := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		if array, ok := d.Get(block).([]interface{}); ok && len(array) > 0 {
+			found = append(found, block)
+		}
+	}
+
+	if len(found) == 0 {
+		return fmt.Errorf("Invalid %s type: no %s block was set. Expected exactly one of: %s", kind, kind, strings.Join(blocks, ", "))
+	}
+	return fmt.Errorf("Invalid %s type: found block(s) %s but could not read them. Expected exactly one of: %s", kind, strings.Join(found, ", "), strings.Join(blocks, ", "))
+}
+
 func getNullableInt(d *schema.ResourceData, key string) *int {
 	value, ok := d.GetOk(key)
 	if !ok {
@@ -88,6 +123,23 @@ func getNullableString(d *schema.ResourceData, key string) *string {
 	return &stringValue
 }
 
+// Returns nil for an optional boolean which was not set, so that unset
+// and false can be told apart when serialising pointer fields. Within a
+// block GetOkExists can't tell a removed key from one still held in
+// state, so the keys read with this are Optional and Computed: left
+// unset, the server's default is used on create and kept from then on.
+func optionalBool(d *schema.ResourceData, key string) *bool {
+	value, ok := d.GetOkExists(key)
+	if !ok {
+		return nil
+	}
+	boolValue, ok := value.(bool)
+	if !ok {
+		return nil
+	}
+	return &boolValue
+}
+
 func getNullableMapString(d map[string]interface{}, key string) *string {
 	rawValue, ok := d[key]
 	if ok {
@@ -114,6 +166,57 @@ func validateAnamlIdentifier() schema.SchemaValidateFunc {
 	return validation.StringMatch(identifierPattern, "Must be parsable as an integer")
 }
 
+// Schema names are given on their own, without the database they belong
+// to, which is set elsewhere as described by databaseHint.
+func validateSchemaName(databaseHint string) schema.SchemaValidateFunc {
+	return validation.All(
+		validation.StringIsNotWhiteSpace,
+		validation.StringMatch(schemaNamePattern, "Must be a schema name on its own, without a database or whitespace, "+databaseHint),
+	)
+}
+
+// Owners are users, given by name or ID with an optional "user:"
+// prefix. Unlike principals they can't be groups.
+func validateOwner() schema.SchemaValidateFunc {
+	return validation.All(
+		validation.StringIsNotWhiteSpace,
+		validation.StringDoesNotMatch(regexp.MustCompile(`^group:`), "Must name a user, only a user can be an owner"),
+	)
+}
+
+// Ignores differences between identifiers with the same integer value,
+// such as "007" and "7".
+func suppressIdentifierDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldID, err := strconv.Atoi(strings.TrimSpace(old))
+	if err != nil {
+		return false
+	}
+	newID, err := strconv.Atoi(strings.TrimSpace(new))
+	if err != nil {
+		return false
+	}
+	return oldID == newID
+}
+
+// Hashes set elements which are identifiers by their integer value, so
+// that "007" and "7" are the same element.
+func hashIdentifier(v interface{}) int {
+	s, _ := v.(string)
+	if id, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+		return schema.HashString(strconv.Itoa(id))
+	}
+	return schema.HashString(s)
+}
+
+func validateTimeZone() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		if _, err := time.LoadLocation(i.(string)); err != nil {
+			return nil, []error{fmt.Errorf("expected %s to be a time zone such as UTC or Australia/Sydney: %v", k, err)}
+		}
+		return nil, nil
+	}
+}
+
 func ValidateDuration() schema.SchemaValidateFunc {
 	return func(i interface{}, k string) ([]string, []error) {
 		_, err := time.ParseDuration(i.(string))
@@ -124,6 +227,121 @@ func ValidateDuration() schema.SchemaValidateFunc {
 	}
 }
 
+var anamlDurationPattern = regexp.MustCompile(`(?i)^P(?:([0-9]+)D)?(T(?:([0-9]+)H)?(?:([0-9]+)M)?(?:([0-9]+)(?:[.,]([0-9]{1,9}))?S)?)?$`)
+
+// Parses a duration in the ISO-8601 form the server uses, such as PT48H
+// or P7DT12H. Only days, hours, minutes and seconds are allowed, as the
+// length of a month or year varies.
+func parseAnamlDuration(value string) (time.Duration, error) {
+	match := anamlDurationPattern.FindStringSubmatch(value)
+	if match == nil || match[2] == "T" || (match[1] == "" && match[2] == "") {
+		return 0, fmt.Errorf("Invalid duration %q, expected an ISO-8601 duration of days, hours, minutes and seconds such as PT48H or P7DT12H", value)
+	}
+
+	var res time.Duration
+	for i, unit := range map[int]time.Duration{1: 24 * time.Hour, 3: time.Hour, 4: time.Minute, 5: time.Second} {
+		if match[i] == "" {
+			continue
+		}
+		amount, err := strconv.ParseInt(match[i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid duration %q: %v", value, err)
+		}
+		if amount > int64(math.MaxInt64-res)/int64(unit) {
+			return 0, fmt.Errorf("Invalid duration %q, it's too long", value)
+		}
+		res += time.Duration(amount) * unit
+	}
+	if fraction := match[6]; fraction != "" {
+		nanos, _ := strconv.Atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+		if time.Duration(nanos) > math.MaxInt64-res {
+			return 0, fmt.Errorf("Invalid duration %q, it's too long", value)
+		}
+		res += time.Duration(nanos)
+	}
+
+	if res <= 0 {
+		return 0, fmt.Errorf("Invalid duration %q, it must be longer than zero", value)
+	}
+	return res, nil
+}
+
+func validateAnamlDuration() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		if _, err := parseAnamlDuration(i.(string)); err != nil {
+			return nil, []error{fmt.Errorf("%s: %v", k, err)}
+		}
+		return nil, nil
+	}
+}
+
+// Validates a comma separated list of Kafka bootstrap servers, each of
+// which must be of the form host:port.
+func validateBootstrapServers() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+
+		var errs []error
+		for _, server := range strings.Split(v, ",") {
+			server = strings.TrimSpace(server)
+			if server == "" {
+				errs = append(errs, fmt.Errorf("%s contains an empty server entry: %q", k, v))
+				continue
+			}
+			host, port, err := net.SplitHostPort(server)
+			if err != nil || host == "" {
+				errs = append(errs, fmt.Errorf("%s entry %q must be of the form host:port", k, server))
+				continue
+			}
+			portNumber, err := strconv.Atoi(port)
+			if err != nil || portNumber < 1 || portNumber > 65535 {
+				errs = append(errs, fmt.Errorf("%s entry %q has an invalid port, expected a number between 1 and 65535", k, server))
+			}
+		}
+		return nil, errs
+	}
+}
+
+var colourPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Validates a colour given as a hex triplet, #RRGGBB.
+func validateColour() schema.SchemaValidateFunc {
+	return validation.StringMatch(colourPattern, "must be a colour of the form #RRGGBB")
+}
+
+// Colours are case-insensitive, and stored in lowercase.
+func normaliseColour(v interface{}) string {
+	return strings.ToLower(v.(string))
+}
+
+// Validates that a string is a single emoji (or other character), made of
+// exactly one grapheme cluster. The vendored segmenter follows Unicode 9,
+// which splits most zero width joiner sequences such as 👩‍💻, so a
+// cluster ending in a joiner is counted together with the next one.
+func validateEmoji() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+
+		clusters, err := textseg.AllTokens([]byte(v), textseg.ScanGraphemeClusters)
+		count := 0
+		for i := range clusters {
+			if i == 0 || !strings.HasSuffix(string(clusters[i-1]), "\u200d") {
+				count++
+			}
+		}
+		if err != nil || count != 1 {
+			return nil, []error{fmt.Errorf("expected %s to be a single emoji, got %q", k, v)}
+		}
+		return nil, nil
+	}
+}
+
 func validateMapKeysAnamlIdentifier() schema.SchemaValidateDiagFunc {
 	return validation.MapKeyMatch(identifierPattern, "Map keys must be parsable as an integer")
 }
@@ -151,3 +369,50 @@ func unmarshalIdAndVersion(input []byte, idAndVersion *IdAndVersion) error {
 	}
 	return nil
 }
+
+// AuditVersion is an object's version as reported by the server, which
+// may be given as either a string or a number.
+type AuditVersion string
+
+func (v *AuditVersion) UnmarshalJSON(buf []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return err
+	}
+	switch value := raw.(type) {
+	case string:
+		*v = AuditVersion(value)
+	case float64:
+		*v = AuditVersion(strconv.FormatFloat(value, 'f', -1, 64))
+	default:
+		*v = ""
+	}
+	return nil
+}
+
+// Kafka properties are only sent for Kafka sources and destinations, as
+// some server versions reject a null kafkaPropertiesProviders. Kafka
+// types always build a non-nil list, so one without properties is still
+// sent as [].
+func (s Source) MarshalJSON() ([]byte, error) {
+	type plain Source
+	return json.Marshal(struct {
+		plain
+		KafkaProperties *[]SensitiveAttribute `json:"kafkaPropertiesProviders,omitempty"`
+	}{plain(s), kafkaPropertiesProviders(s.KafkaProperties)})
+}
+
+func (d Destination) MarshalJSON() ([]byte, error) {
+	type plain Destination
+	return json.Marshal(struct {
+		plain
+		KafkaProperties *[]SensitiveAttribute `json:"kafkaPropertiesProviders,omitempty"`
+	}{plain(d), kafkaPropertiesProviders(d.KafkaProperties)})
+}
+
+func kafkaPropertiesProviders(properties []SensitiveAttribute) *[]SensitiveAttribute {
+	if properties == nil {
+		return nil
+	}
+	return &properties
+}
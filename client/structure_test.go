@@ -0,0 +1,385 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestOptionalBool(t *testing.T) {
+	yes, no := true, false
+	cases := []struct {
+		name string
+		csv  map[string]interface{}
+		want *bool
+	}{
+		{"unset", map[string]interface{}{}, nil},
+		{"false", map[string]interface{}{"include_header": false}, &no},
+		{"true", map[string]interface{}{"include_header": true}, &yes},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			s3 := map[string]interface{}{"bucket": "b", "path": "/p", "file_format": "csv"}
+			for k, v := range tt.csv {
+				s3[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, ResourceSource().Schema, map[string]interface{}{
+				"name": "source",
+				"s3":   []interface{}{s3},
+			})
+
+			got := optionalBool(d, "s3.0.include_header")
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("optionalBool = %v, want %v", formatBool(got), formatBool(tt.want))
+			}
+			if ff := composeFileFormat(d, "s3"); (ff.QuoteAll != nil) || (ff.IncludeHeader == nil) != (tt.want == nil) {
+				t.Errorf("composeFileFormat quote_all = %v, include_header = %v", formatBool(ff.QuoteAll), formatBool(ff.IncludeHeader))
+			}
+		})
+	}
+}
+
+func TestOptionalBoolKeepsValueFromState(t *testing.T) {
+	d := ResourceSource().Data(&terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"s3.#":                       "1",
+			"s3.0.bucket":                "b",
+			"s3.0.path":                  "/p",
+			"s3.0.file_format":           "csv",
+			"s3.0.include_header":        "false",
+			"s3.0.recursive_file_lookup": "true",
+		},
+	})
+
+	if got := optionalBool(d, "s3.0.include_header"); got == nil || *got {
+		t.Errorf("include_header = %v, want false", formatBool(got))
+	}
+	if got := optionalBool(d, "s3.0.recursive_file_lookup"); got == nil || !*got {
+		t.Errorf("recursive_file_lookup = %v, want true", formatBool(got))
+	}
+}
+
+func formatBool(b *bool) string {
+	if b == nil {
+		return "nil"
+	}
+	if *b {
+		return "true"
+	}
+	return "false"
+}
+
+func TestValidateEmoji(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"single codepoint", "🔥", true},
+		{"plain character", "A", true},
+		{"flag", "🇦🇺", true},
+		{"skin tone", "👍🏽", true},
+		{"zero width joiner sequence", "👩‍💻", true},
+		{"zero width joiner sequence with a modifier", "👩🏽‍💻", true},
+		{"family", "👨‍👩‍👧", true},
+		{"keycap", "1️⃣", true},
+		{"empty", "", false},
+		{"two emoji", "🔥🔥", false},
+		{"two flags", "🇦🇺🇳🇿", false},
+		{"word", "fire", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateEmoji()(tt.value, "emoji")
+			if valid := len(errs) == 0; valid != tt.valid {
+				t.Errorf("validateEmoji(%q) valid = %v, want %v (errors: %v)", tt.value, valid, tt.valid, errs)
+			}
+		})
+	}
+}
+
+func TestValidateColour(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"lowercase", "#ff8800", true},
+		{"uppercase", "#FF8800", true},
+		{"mixed case", "#Ff88aA", true},
+		{"missing hash", "ff8800", false},
+		{"short form", "#f80", false},
+		{"with alpha", "#ff8800ff", false},
+		{"not hex", "#gg8800", false},
+		{"colour name", "orange", false},
+		{"trailing space", "#ff8800 ", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateColour()(tt.value, "colour")
+			if valid := len(errs) == 0; valid != tt.valid {
+				t.Errorf("validateColour(%q) valid = %v, want %v (errors: %v)", tt.value, valid, tt.valid, errs)
+			}
+		})
+	}
+
+	if got := normaliseColour("#FF88aA"); got != "#ff88aa" {
+		t.Errorf("normaliseColour(%q) = %q, want %q", "#FF88aA", got, "#ff88aa")
+	}
+}
+
+func TestValidateBootstrapServers(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  string
+		errors int
+	}{
+		{"one server", "kafka:9092", 0},
+		{"several servers", "kafka-1:9092,kafka-2:9092,kafka-3:9093", 0},
+		{"spaces after commas", "kafka-1:9092, kafka-2:9092", 0},
+		{"ip address", "10.0.0.1:9092", 0},
+		{"ipv6 address", "[::1]:9092", 0},
+		{"highest port", "kafka:65535", 0},
+		{"missing port", "kafka", 1},
+		{"missing host", ":9092", 1},
+		{"url", "PLAINTEXT://kafka:9092", 1},
+		{"port zero", "kafka:0", 1},
+		{"port too high", "kafka:65536", 1},
+		{"port not a number", "kafka:http", 1},
+		{"trailing comma", "kafka:9092,", 1},
+		{"empty", "", 1},
+		{"each bad entry", "kafka-1,kafka-2:0,kafka-3:9092", 2},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateBootstrapServers()(tt.value, "bootstrap_servers")
+			if len(errs) != tt.errors {
+				t.Errorf("validateBootstrapServers(%q) gave %d errors, want %d (errors: %v)", tt.value, len(errs), tt.errors, errs)
+			}
+		})
+	}
+}
+
+func TestKafkaPropertiesProvidersPayload(t *testing.T) {
+	property := []SensitiveAttribute{{Key: "max.poll.records"}}
+	cases := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"s3 source", Source{Type: "s3"}, ""},
+		{"kafka source without properties", Source{Type: "kafka", KafkaProperties: []SensitiveAttribute{}}, "[]"},
+		{"kafka source", Source{Type: "kafka", KafkaProperties: property}, `[{"key":"max.poll.records","valueConfig":null}]`},
+		{"s3 destination", Destination{Type: "s3"}, ""},
+		{"kafka destination without properties", Destination{Type: "kafka", KafkaProperties: []SensitiveAttribute{}}, "[]"},
+		{"kafka destination", Destination{Type: "kafka", KafkaProperties: property}, `[{"key":"max.poll.records","valueConfig":null}]`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var payload map[string]json.RawMessage
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatal(err)
+			}
+			got, ok := payload["kafkaPropertiesProviders"]
+			if tt.want == "" {
+				if ok {
+					t.Errorf("kafkaPropertiesProviders = %s, want no key", got)
+				}
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("kafkaPropertiesProviders = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAnamlDuration(t *testing.T) {
+	cases := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"PT48H", 48 * time.Hour},
+		{"P7D", 7 * 24 * time.Hour},
+		{"P7DT12H", 7*24*time.Hour + 12*time.Hour},
+		{"PT30M", 30 * time.Minute},
+		{"PT1H30M", 90 * time.Minute},
+		{"PT45S", 45 * time.Second},
+		{"PT1.5S", 1500 * time.Millisecond},
+		{"PT0,25S", 250 * time.Millisecond},
+		{"PT0.000000001S", time.Nanosecond},
+		{"pt48h", 48 * time.Hour},
+		{"P1DT0H", 24 * time.Hour},
+		{"", 0},
+		{"P", 0},
+		{"PT", 0},
+		{"P1DT", 0},
+		{"48H", 0},
+		{"PT0S", 0},
+		{"P0D", 0},
+		{"P1M", 0},
+		{"P1Y", 0},
+		{"P1W", 0},
+		{"PT-1H", 0},
+		{"PT1.5H", 0},
+		{"PT1.0000000001S", 0},
+		{"48h", 0},
+		{" PT48H", 0},
+		{"PT99999999999999999999H", 0},
+		{"P1000000D", 0},
+		{"P300000D", 0},
+		{"PT9223372036.9S", 0},
+		{"PT9999999999H", 0},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseAnamlDuration(tt.value)
+			if tt.want == 0 {
+				if err == nil {
+					t.Errorf("parseAnamlDuration(%q) = %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAnamlDuration(%q) err = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseAnamlDuration(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuppressIdentifierDiff(t *testing.T) {
+	cases := []struct {
+		old, new string
+		suppress bool
+	}{
+		{"7", "7", true},
+		{"7", "007", true},
+		{"007", "7", true},
+		{"0", "000", true},
+		{"7", "8", false},
+		{"70", "7", false},
+		{"", "0", false},
+		{"7", "", false},
+		{"7", "customer", false},
+		{"customer", "customer", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.old+" to "+tt.new, func(t *testing.T) {
+			if got := suppressIdentifierDiff("entity", tt.old, tt.new, nil); got != tt.suppress {
+				t.Errorf("suppressIdentifierDiff(%q, %q) = %v, want %v", tt.old, tt.new, got, tt.suppress)
+			}
+		})
+	}
+}
+
+func TestIdentifierSetsCompareByValue(t *testing.T) {
+	resources := []struct {
+		name     string
+		resource *schema.Resource
+		config   func(ids []interface{}) map[string]interface{}
+	}{
+		{"feature set features", ResourceFeatureSet(), func(ids []interface{}) map[string]interface{} {
+			return map[string]interface{}{"name": "daily", "entity": "1", "features": ids}
+		}},
+		{"table monitoring tables", ResourceTableMonitoring(), func(ids []interface{}) map[string]interface{} {
+			return map[string]interface{}{
+				"name":    "daily",
+				"enabled": true,
+				"include": []interface{}{map[string]interface{}{"tables": ids}},
+			}
+		}},
+		{"table monitoring exclusions", ResourceTableMonitoring(), func(ids []interface{}) map[string]interface{} {
+			return map[string]interface{}{
+				"name":    "daily",
+				"enabled": true,
+				"auto":    []interface{}{map[string]interface{}{"exclude": ids}},
+			}
+		}},
+	}
+	cases := []struct {
+		name      string
+		old, new  []interface{}
+		wantEmpty bool
+	}{
+		{"same", []interface{}{"7", "9"}, []interface{}{"7", "9"}, true},
+		{"leading zeros", []interface{}{"7", "9"}, []interface{}{"007", "9"}, true},
+		{"different", []interface{}{"7", "9"}, []interface{}{"8", "9"}, false},
+		{"added", []interface{}{"7"}, []interface{}{"007", "9"}, false},
+	}
+
+	for _, r := range resources {
+		for _, tt := range cases {
+			t.Run(r.name+"/"+tt.name, func(t *testing.T) {
+				d := schema.TestResourceDataRaw(t, r.resource.Schema, r.config(tt.old))
+				d.SetId("5")
+
+				config := terraform.NewResourceConfigRaw(r.config(tt.new))
+				if diags := r.resource.Validate(config); diags.HasError() {
+					t.Fatalf("diags = %v", diags)
+				}
+				diff, err := r.resource.Diff(context.Background(), d.State(), config, &Client{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff.Empty() != tt.wantEmpty {
+					t.Errorf("diff = %v, want empty %v", diff, tt.wantEmpty)
+				}
+			})
+		}
+	}
+}
+
+func TestValidateSchemaName(t *testing.T) {
+	cases := []struct {
+		value string
+		valid bool
+	}{
+		{"public", true},
+		{"analytics_2024", true},
+		{"Sales", true},
+		{"my-schema", true},
+		{"", false},
+		{"   ", false},
+		{"db.public", false},
+		{"catalog.db.public", false},
+		{".public", false},
+		{"public.", false},
+		{"my schema", false},
+		{"public\n", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.value, func(t *testing.T) {
+			_, errs := validateSchemaName("give the database in the url instead")(tt.value, "schema")
+			if valid := len(errs) == 0; valid != tt.valid {
+				t.Errorf("validateSchemaName(%q) valid = %v, want %v (errors: %v)", tt.value, valid, tt.valid, errs)
+			}
+			for _, err := range errs {
+				if tt.value != "" && strings.TrimSpace(tt.value) != "" && !strings.Contains(err.Error(), "give the database in the url instead") {
+					t.Errorf("error %q doesn't say where the database goes", err)
+				}
+			}
+		})
+	}
+}
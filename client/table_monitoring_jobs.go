@@ -30,49 +30,48 @@ func (c *Client) GetTableMonitoring(TableMonitoringId string) (*TableMonitoring,
 	return &TableMonitoringJob, nil
 }
 
-func (c *Client) CreateTableMonitoring(creationRequest TableMonitoring) (*TableMonitoring, error) {
+func (c *Client) CreateTableMonitoring(creationRequest TableMonitoring) (*TableMonitoring, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/table-monitoring", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateTableMonitoring(TableMonitoringId string, creationRequest TableMonitoring) error {
+func (c *Client) UpdateTableMonitoring(TableMonitoringId string, creationRequest TableMonitoring) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/table-monitoring/%s", c.HostURL, TableMonitoringId), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteTableMonitoring(TableMonitoringId string) error {
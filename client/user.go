@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -30,49 +31,48 @@ func (c *Client) GetUser(userID string) (*User, error) {
 	return &user, nil
 }
 
-func (c *Client) CreateUser(creationRequest User) (*User, error) {
+func (c *Client) CreateUser(creationRequest User) (*User, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateUser(userID string, creationRequest User) error {
+func (c *Client) UpdateUser(userID string, creationRequest User) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/user/%s", c.HostURL, userID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteUser(userID string) error {
@@ -89,22 +89,55 @@ func (c *Client) DeleteUser(userID string) error {
 	return nil
 }
 
-func (c *Client) UpdateUserPassword(userID string, password *string) error {
+func (c *Client) UpdateUserPassword(userID string, password *string) ([]string, error) {
 	updateRequest := ChangeOtherPasswordRequest{Password: password}
 	rb, err := json.Marshal(updateRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/user/%s/change-password", c.HostURL, userID), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
+}
+
+// FindUser looks up a user by either their numeric ID or their name.
+func (c *Client) FindUser(user string) (*User, error) {
+	if _, err := strconv.Atoi(user); err == nil {
+		return c.GetUser(user)
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/user", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("name", user)
+	req.URL.RawQuery = q.Encode()
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	item := User{}
+	found, err := unmarshalNamed(body, "user", user, &item)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &item, nil
 }
@@ -30,49 +30,49 @@ func (c *Client) GetUserGroup(userGroupId string) (*UserGroup, error) {
 	return &userGroup, nil
 }
 
-func (c *Client) CreateUserGroup(creationRequest UserGroup) (*UserGroup, error) {
+func (c *Client) CreateUserGroup(creationRequest UserGroup) (*UserGroup, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user-group", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var idAndVersion IdAndVersion
 	err = unmarshalIdAndVersion(body, &idAndVersion)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = idAndVersion.ID
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateUserGroup(userGroupId string, creationRequest UserGroup) error {
+func (c *Client) UpdateUserGroup(userGroupId string, creationRequest UserGroup) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/user-group/%s", c.HostURL, userGroupId), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteUserGroup(userGroupId string) error {
@@ -88,3 +88,31 @@ func (c *Client) DeleteUserGroup(userGroupId string) error {
 
 	return nil
 }
+
+func (c *Client) FindUserGroup(name string) (*UserGroup, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/user-group", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("name", name)
+	req.URL.RawQuery = q.Encode()
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	userGroup := UserGroup{}
+	found, err := unmarshalNamed(body, "user group", name, &userGroup)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &userGroup, nil
+}
@@ -30,49 +30,48 @@ func (c *Client) GetViewMaterialisationJob(id string) (*ViewMaterialisationJob,
 	return &MaterialisedView, nil
 }
 
-func (c *Client) CreateViewMaterialisationJob(creationRequest ViewMaterialisationJob) (*ViewMaterialisationJob, error) {
+func (c *Client) CreateViewMaterialisationJob(creationRequest ViewMaterialisationJob) (*ViewMaterialisationJob, []string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/view-materialisation", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	body, err := c.doRequest(req)
+	body, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	V, err := unmarshalCreatedID(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateViewMaterialisationJob(id string, creationRequest ViewMaterialisationJob) error {
+func (c *Client) UpdateViewMaterialisationJob(id string, creationRequest ViewMaterialisationJob) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/view-materialisation/%s", c.HostURL, id), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteViewMaterialisationJob(id string) error {
@@ -109,8 +108,8 @@ func (c *Client) FindViewMaterialisationJobByName(name string) (*ViewMaterialisa
 	}
 
 	item := ViewMaterialisationJob{}
-	err = json.Unmarshal(body, &item)
-	if err != nil {
+	found, err := unmarshalNamed(body, "view materialisation job", name, &item)
+	if err != nil || !found {
 		return nil, err
 	}
 
@@ -30,49 +30,91 @@ func (c *Client) GetWebhook(WebhookId string) (*Webhook, error) {
 	return &Webhook, nil
 }
 
-func (c *Client) CreateWebhook(creationRequest Webhook) (*Webhook, error) {
-	rb, err := json.Marshal(creationRequest)
+func (c *Client) ListWebhooks() ([]Webhook, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/webhook", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/webhook", c.HostURL), strings.NewReader(string(rb)))
+	body, err := c.doRequest(req)
 	if err != nil {
 		return nil, err
 	}
+	if body == nil {
+		return nil, nil
+	}
 
-	body, err := c.doRequest(req)
+	webhooks := []Webhook{}
+	err = json.Unmarshal(body, &webhooks)
 	if err != nil {
 		return nil, err
 	}
 
-	var V int
-	err = json.Unmarshal(body, &V)
+	return webhooks, nil
+}
+
+// Finds the webhook with exactly the given name.
+func (c *Client) FindWebhook(name string) (*Webhook, error) {
+	webhooks, err := c.ListWebhooks()
 	if err != nil {
 		return nil, err
 	}
 
+	var found *Webhook
+	for i := range webhooks {
+		if webhooks[i].Name == name {
+			if found != nil {
+				return nil, fmt.Errorf("More than one webhook matches %q, refer to it by ID instead", name)
+			}
+			found = &webhooks[i]
+		}
+	}
+
+	return found, nil
+}
+
+func (c *Client) CreateWebhook(creationRequest Webhook) (*Webhook, []string, error) {
+	rb, err := json.Marshal(creationRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/webhook", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, warnings, err := c.doWriteRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	V, err := unmarshalCreatedID(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	creationRequest.ID = V
-	return &creationRequest, nil
+	return &creationRequest, warnings, nil
 }
 
-func (c *Client) UpdateWebhook(WebhookId string, creationRequest Webhook) error {
+func (c *Client) UpdateWebhook(WebhookId string, creationRequest Webhook) ([]string, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/webhook/%s", c.HostURL, WebhookId), strings.NewReader(string(rb)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
+	_, warnings, err := c.doWriteRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func (c *Client) DeleteWebhook(WebhookId string) error {
@@ -88,3 +130,26 @@ func (c *Client) DeleteWebhook(WebhookId string) error {
 
 	return nil
 }
+
+func (c *Client) TestWebhook(WebhookId string) (*WebhookTestResult, error) {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/webhook/%s/test", c.HostURL, WebhookId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, nil
+	}
+
+	result := WebhookTestResult{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -3,5 +3,6 @@ module anaml.io/terraform
 go 1.14
 
 require (
+	github.com/apparentlymart/go-textseg v1.0.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.3.0
 )
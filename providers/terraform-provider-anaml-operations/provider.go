@@ -1,11 +1,13 @@
 package main
 
 import (
+	"regexp"
 	"time"
 
 	anaml "anaml.io/terraform/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -33,19 +35,97 @@ func Provider() *schema.Provider {
 				Default:      "30s",
 				ValidateFunc: anaml.ValidateDuration(),
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				Description:  "How many times to retry a request which is rate limited by the server",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"api_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The version of the server API to request, such as v2. Unset uses the server's default version",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^v[0-9]+$`), "must be a version such as v2"),
+			},
+			"disable_keep_alives": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to open a new connection for every request. Set this when a proxy or load balancer in front of the server drops idle connections, causing connection reset errors",
+			},
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "The most idle connections to keep open to the server. Lower it when a proxy limits open connections. Zero keeps the default",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"user_agent_suffix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Text appended to the User-Agent of every request, to identify the provider's traffic",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "The most requests to send to the server at once, regardless of Terraform's parallelism. Zero means no limit",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"default_labels": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Labels to attach to every object managed by the provider",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"case_insensitive_labels": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the server treats labels which differ only in case as the same label. When set, labels are sent in lower case and read back as written in the configuration",
+			},
+			"default_cluster": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The name or ID of the cluster to run jobs on when a resource doesn't set a cluster",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"default_attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Attributes (key value pairs) to attach to every object managed by the provider",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"anaml-operations_cluster":       anaml.DataSourceCluster(),
-			"anaml-operations_destination":   anaml.DataSourceDestination(),
-			"anaml-operations_source":        anaml.DataSourceSource(),
-			"anaml-operations_feature_store": anaml.DataSourceFeatureStore(),
+			"anaml-operations_access_token":           anaml.DataSourceAccessToken(),
+			"anaml-operations_access_tokens":          anaml.DataSourceAccessTokens(),
+			"anaml-operations_attribute_restrictions": anaml.DataSourceAttributeRestrictions(),
+			"anaml-operations_branch_protection":      anaml.DataSourceBranchProtection(),
+			"anaml-operations_branch_protections":     anaml.DataSourceBranchProtections(),
+			"anaml-operations_cluster":                anaml.DataSourceCluster(),
+			"anaml-operations_destination":            anaml.DataSourceDestination(),
+			"anaml-operations_source":                 anaml.DataSourceSource(),
+			"anaml-operations_source_config_json":     anaml.DataSourceSourceConfigJSON(),
+			"anaml-operations_source_access_rules":    anaml.DataSourceSourceAccessRules(),
+			"anaml-operations_feature_set":            anaml.DataSourceFeatureSet(),
+			"anaml-operations_feature_store":          anaml.DataSourceFeatureStore(),
+			"anaml-operations_inventory":              anaml.DataSourceInventory(),
+			"anaml-operations_label_restrictions":     anaml.DataSourceLabelRestrictions(),
+			"anaml-operations_roles":                  anaml.DataSourceRoles(),
+			"anaml-operations_spark_property_bundle":  anaml.DataSourceSparkPropertyBundle(),
+			"anaml-operations_webhook":                anaml.DataSourceWebhook(),
+			"anaml-operations_webhooks":               anaml.DataSourceWebhooks(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"anaml-operations_access_token":             anaml.ResourceAccessToken(),
 			"anaml-operations_attribute_restriction":    anaml.ResourceAttributeRestriction(),
 			"anaml-operations_branch_protection":        anaml.ResourceBranchProtection(),
+			"anaml-operations_bulk_tagging":             anaml.ResourceBulkTagging(),
 			"anaml-operations_caching":                  anaml.ResourceTableCaching(),
 			"anaml-operations_cluster":                  anaml.ResourceCluster(),
 			"anaml-operations_destination":              anaml.ResourceDestination(),
@@ -54,6 +134,7 @@ func Provider() *schema.Provider {
 			"anaml-operations_label_restriction":        anaml.ResourceLabelRestriction(),
 			"anaml-operations_monitoring":               anaml.ResourceTableMonitoring(),
 			"anaml-operations_source":                   anaml.ResourceSource(),
+			"anaml-operations_spark_property_bundle":    anaml.ResourceSparkPropertyBundle(),
 			"anaml-operations_user_group":               anaml.ResourceUserGroup(),
 			"anaml-operations_user":                     anaml.ResourceUser(),
 			"anaml-operations_view_materialisation_job": anaml.ResourceViewMaterialisationJob(),
@@ -88,5 +169,22 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, err
 	}
 
+	c.MaxRetries = d.Get("max_retries").(int)
+	c.APIVersion = d.Get("api_version").(string)
+	c.UserAgentSuffix = d.Get("user_agent_suffix").(string)
+	c.SetMaxConcurrentRequests(d.Get("max_concurrent_requests").(int))
+	c.SetConnectionReuse(d.Get("disable_keep_alives").(bool), d.Get("max_idle_conns").(int))
+	c.CaseInsensitiveLabels = d.Get("case_insensitive_labels").(bool)
+	c.DefaultCluster = d.Get("default_cluster").(string)
+
+	for _, label := range d.Get("default_labels").(*schema.Set).List() {
+		c.DefaultLabels = append(c.DefaultLabels, label.(string))
+	}
+
+	c.DefaultAttributes = make(map[string]string)
+	for key, value := range d.Get("default_attributes").(map[string]interface{}) {
+		c.DefaultAttributes[key] = value.(string)
+	}
+
 	return c, nil
 }
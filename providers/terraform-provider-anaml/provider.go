@@ -1,11 +1,13 @@
 package main
 
 import (
+	"regexp"
 	"time"
 
 	anaml "anaml.io/terraform/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -38,15 +40,88 @@ func Provider() *schema.Provider {
 				Default:      "30s",
 				ValidateFunc: anaml.ValidateDuration(),
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				Description:  "How many times to retry a request which is rate limited by the server",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"api_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The version of the server API to request, such as v2. Unset uses the server's default version",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^v[0-9]+$`), "must be a version such as v2"),
+			},
+			"disable_keep_alives": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to open a new connection for every request. Set this when a proxy or load balancer in front of the server drops idle connections, causing connection reset errors",
+			},
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "The most idle connections to keep open to the server. Lower it when a proxy limits open connections. Zero keeps the default",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"user_agent_suffix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Text appended to the User-Agent of every request, to identify the provider's traffic",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "The most requests to send to the server at once, regardless of Terraform's parallelism. Zero means no limit",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"default_labels": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Labels to attach to every object managed by the provider",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"case_insensitive_labels": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the server treats labels which differ only in case as the same label. When set, labels are sent in lower case and read back as written in the configuration",
+			},
+			"disable_feature_batching": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to create each feature with its own request. By default features created in the same apply are grouped into batch requests, each waiting up to 100ms for others to join it",
+			},
+			"validate_feature_tables": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to check when planning that each feature's table is a root or event store table. This reads every referenced table from the server",
+			},
+			"default_attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Attributes (key value pairs) to attach to every object managed by the provider",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"anaml_entity":            anaml.DataSourceEntity(),
-			"anaml_entity_population": anaml.DataSourceEntityPopulation(),
-			"anaml_table":             anaml.DataSourceTable(),
-			"anaml_feature":           anaml.DataSourceFeature(),
-			"anaml_feature_set":       anaml.DataSourceFeatureSet(),
-			"anaml_feature_template":  anaml.DataSourceFeatureTemplate(),
+			"anaml_entity":              anaml.DataSourceEntity(),
+			"anaml_entity_population":   anaml.DataSourceEntityPopulation(),
+			"anaml_table":               anaml.DataSourceTable(),
+			"anaml_feature":             anaml.DataSourceFeature(),
+			"anaml_feature_config_json": anaml.DataSourceFeatureConfigJSON(),
+			"anaml_feature_lineage":     anaml.DataSourceFeatureLineage(),
+			"anaml_feature_set":         anaml.DataSourceFeatureSet(),
+			"anaml_feature_sets":        anaml.DataSourceFeatureSets(),
+			"anaml_feature_template":    anaml.DataSourceFeatureTemplate(),
+			"anaml_preview":             anaml.DataSourcePreview(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -88,5 +163,23 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, err
 	}
 
+	c.MaxRetries = d.Get("max_retries").(int)
+	c.APIVersion = d.Get("api_version").(string)
+	c.UserAgentSuffix = d.Get("user_agent_suffix").(string)
+	c.SetMaxConcurrentRequests(d.Get("max_concurrent_requests").(int))
+	c.SetConnectionReuse(d.Get("disable_keep_alives").(bool), d.Get("max_idle_conns").(int))
+	c.CaseInsensitiveLabels = d.Get("case_insensitive_labels").(bool)
+	c.ValidateFeatureTables = d.Get("validate_feature_tables").(bool)
+	c.DisableFeatureBatching = d.Get("disable_feature_batching").(bool)
+
+	for _, label := range d.Get("default_labels").(*schema.Set).List() {
+		c.DefaultLabels = append(c.DefaultLabels, label.(string))
+	}
+
+	c.DefaultAttributes = make(map[string]string)
+	for key, value := range d.Get("default_attributes").(map[string]interface{}) {
+		c.DefaultAttributes[key] = value.(string)
+	}
+
 	return c, nil
 }